@@ -73,6 +73,24 @@ func (suite *DriverTestSuite) TestConfigToDsnWithSchema() {
 	suite.Equal("exa:localhost:8563;user=sys;password=exasol;schema=schemaName", config.String())
 }
 
+func (suite *DriverTestSuite) TestConfigToDsnWithStatementCacheSize() {
+	config := NewConfig("sys", "exasol").
+		StatementCacheSize(16)
+	suite.Equal("exa:localhost:8563;user=sys;password=exasol;statementcachesize=16", config.String())
+}
+
+func (suite *DriverTestSuite) TestConfigToDsnWithImportCompression() {
+	config := NewConfig("sys", "exasol").
+		ImportCompression(true)
+	suite.Equal("exa:localhost:8563;user=sys;password=exasol;importcompression=1", config.String())
+}
+
+func (suite *DriverTestSuite) TestConfigToDsnWithParseTime() {
+	config := NewConfig("sys", "exasol").
+		ParseTime(true)
+	suite.Equal("exa:localhost:8563;user=sys;password=exasol;parsetime=1", config.String())
+}
+
 func (suite *DriverTestSuite) TestConfigToDsnWithDefaultValues() {
 	config := NewConfig("sys", "exasol")
 	suite.Equal("exa:localhost:8563;user=sys;password=exasol", config.String())
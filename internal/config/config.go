@@ -16,7 +16,10 @@ type Config struct {
 	FetchSize                 int // Fetch size in kB
 	QueryTimeout              int // query timeout in seconds
 	Compression               bool
+	ImportCompression         bool // gzip compress the data of local IMPORT statements
 	ResultSetMaxRows          int
+	StatementCacheSize        int  // maximum number of cached prepared statements, 0 disables the cache
+	ParseTime                 bool // return DATE and TIMESTAMP values as time.Time instead of strings
 	Encryption                bool
 	ValidateServerCertificate bool
 	CertificateFingerprint    string
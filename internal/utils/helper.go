@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"database/sql/driver"
 	"fmt"
 	mathRand "math/rand"
 	"os"
@@ -13,17 +12,6 @@ import (
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 )
 
-func NamedValuesToValues(namedValues []driver.NamedValue) ([]driver.Value, error) {
-	values := make([]driver.Value, len(namedValues))
-	for index, namedValue := range namedValues {
-		if namedValue.Name != "" {
-			return nil, errors.ErrNamedValuesNotSupported
-		}
-		values[index] = namedValue.Value
-	}
-	return values, nil
-}
-
 func BoolToInt(b bool) int {
 	if b {
 		return 1
@@ -35,31 +23,23 @@ func BoolToPtr(b bool) *bool {
 	return &b
 }
 
-const WHITESPACE = `\s+`
-
-var localImportRegex = regexp.MustCompile(`(?ims)^\s*IMPORT[\s(]+.+FROM` + WHITESPACE + `LOCAL` + WHITESPACE + `CSV.*$`)
-
-func IsImportQuery(query string) bool {
-	return localImportRegex.MatchString(query)
+func StringToPtr(s string) *string {
+	return &s
 }
 
-const ROW_SEPARATOR_PLACEHOLDER = "RowSeparatorPlaceholder"
-const QUOTE = `["']`
-
-func namedGroup(name, regexp string) string {
-	return fmt.Sprintf("(?P<%s>%s)", name, regexp)
+func IsImportQuery(query string) bool {
+	_, ok := parseLocalImport(query)
+	return ok
 }
 
-var rowSeparatorQueryRegex = regexp.MustCompile(`(?i)` +
-	`ROW` + WHITESPACE + `SEPARATOR` + WHITESPACE + `=` + WHITESPACE +
-	QUOTE + namedGroup(ROW_SEPARATOR_PLACEHOLDER, "[a-zA-Z]+") + QUOTE)
-
+// GetRowSeparator returns the row separator defined by the ROW SEPARATOR option. The default is LF.
 func GetRowSeparator(query string) string {
-	r := rowSeparatorQueryRegex.FindStringSubmatch(query)
+	tokens := significantTokens(Tokenize(query))
 	separator := "LF"
-	for i, name := range rowSeparatorQueryRegex.SubexpNames() {
-		if name == ROW_SEPARATOR_PLACEHOLDER && len(r) >= i {
-			separator = r[i]
+	for i := 0; i+3 < len(tokens); i++ {
+		if tokens[i].IsWord("ROW") && tokens[i+1].IsWord("SEPARATOR") && tokens[i+2].IsSymbol("=") && isQuoted(tokens[i+3]) {
+			separator = tokens[i+3].Value()
+			break
 		}
 	}
 
@@ -73,20 +53,26 @@ func GetRowSeparator(query string) string {
 	}
 }
 
-const FILE_PLACEHOLDER = "FilePlaceholder"
-
-var fileQueryRegex = regexp.MustCompile(`(?i)` + `FILE` + WHITESPACE +
-	QUOTE + namedGroup(FILE_PLACEHOLDER, `[a-zA-Z0-9:<> \\/._\-~]+`) + QUOTE + ` ?`)
+// GetColumnDelimiter returns the COLUMN DELIMITER that encloses quoted fields of a CSV import, by default '"'.
+// It returns an empty string for FBV files, which have no quoted fields.
+func GetColumnDelimiter(query string) string {
+	statement, ok := parseLocalImport(query)
+	if ok && statement.format() == "FBV" {
+		return ""
+	}
+	tokens := significantTokens(Tokenize(query))
+	for i := 0; i+3 < len(tokens); i++ {
+		if tokens[i].IsWord("COLUMN") && tokens[i+1].IsWord("DELIMITER") && tokens[i+2].IsSymbol("=") && isQuoted(tokens[i+3]) {
+			return tokens[i+3].Value()
+		}
+	}
+	return `"`
+}
 
 func GetFilePaths(query string) ([]string, error) {
-	r := fileQueryRegex.FindAllStringSubmatch(query, -1)
 	var files []string
-	for _, matches := range r {
-		for i, name := range fileQueryRegex.SubexpNames() {
-			if name == FILE_PLACEHOLDER {
-				files = append(files, matches[i])
-			}
-		}
+	for _, clause := range fileClauses(significantTokens(Tokenize(query))) {
+		files = append(files, clause.path.Value())
 	}
 	if len(files) == 0 {
 		return nil, errors.ErrInvalidImportQuery
@@ -103,23 +89,159 @@ func OpenFile(path string) (*os.File, error) {
 }
 
 func UpdateImportQuery(query string, host string, port int) string {
-	if !IsImportQuery(query) {
+	return UpdateParallelImportQuery(query, []string{ProxyLocation(host, port, "")}, false)
+}
+
+// UpdateParallelImportQuery replaces the local files of an IMPORT statement with one file for each proxy location,
+// so that the database loads the data from all proxies in parallel. If compressed is true, the database expects
+// gzip compressed files.
+func UpdateParallelImportQuery(query string, proxyLocations []string, compressed bool) string {
+	statement, ok := parseLocalImport(query)
+	if !ok {
 		return query
 	}
-	r := fileQueryRegex.FindAllStringSubmatch(query, -1)
-	for i, matches := range r {
-		if i == 0 {
-			query = strings.Replace(query, matches[0], "FILE 'data.csv' ", 1)
-		} else {
-			query = strings.Replace(query, matches[0], "", 1)
+	fileName := "data." + strings.ToLower(statement.format())
+	if compressed {
+		fileName += ".gz"
+	}
+	return statement.replaceLocalFiles(proxyLocations, fileName)
+}
+
+// IsCompressedFile returns true if the file is gzip compressed according to its extension.
+func IsCompressedFile(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".gz")
+}
+
+// HasSkipOption returns true if the query skips rows at the beginning of the file, e.g. a header.
+func HasSkipOption(query string) bool {
+	tokens := significantTokens(Tokenize(query))
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].IsWord("SKIP") && tokens[i+1].IsSymbol("=") {
+			return true
+		}
+	}
+	return false
+}
+
+func IsExportQuery(query string) bool {
+	_, ok := parseLocalExport(query)
+	return ok
+}
+
+func UpdateExportQuery(query string, proxyLocation string) string {
+	statement, ok := parseLocalExport(query)
+	if !ok {
+		return query
+	}
+	return statement.replaceLocalFiles([]string{proxyLocation}, "data.csv")
+}
+
+// ProxyLocation returns the location of a proxy for the AT clause of an IMPORT or EXPORT statement.
+// If the public key fingerprint is not empty, the database connects via TLS and verifies the proxy's public key.
+func ProxyLocation(host string, port int, publicKey string) string {
+	if publicKey == "" {
+		return fmt.Sprintf("'http://%s:%d'", host, port)
+	}
+	return fmt.Sprintf("'https://%s:%d' PUBLIC KEY '%s'", host, port, publicKey)
+}
+
+// localFileStatement is an IMPORT or EXPORT statement with local files, e.g. IMPORT ... FROM LOCAL CSV FILE '...'.
+type localFileStatement struct {
+	query string
+	// tokens are the significant tokens of the query
+	tokens []Token
+	// local is the index of the LOCAL keyword in tokens
+	local int
+}
+
+func parseLocalImport(query string) (localFileStatement, bool) {
+	return parseLocalFileStatement(query, "IMPORT", "FROM", "CSV", "FBV")
+}
+
+func parseLocalExport(query string) (localFileStatement, bool) {
+	return parseLocalFileStatement(query, "EXPORT", "INTO", "CSV")
+}
+
+// parseLocalFileStatement parses a statement starting with the given command that contains the keyword LOCAL
+// between the given keyword and one of the formats outside of parentheses, e.g. FROM LOCAL CSV.
+func parseLocalFileStatement(query string, command string, keyword string, formats ...string) (localFileStatement, bool) {
+	tokens := significantTokens(Tokenize(query))
+	if len(tokens) == 0 || !tokens[0].IsWord(command) {
+		return localFileStatement{}, false
+	}
+	depth := 0
+	for i := 1; i+1 < len(tokens); i++ {
+		switch {
+		case tokens[i].IsSymbol("("):
+			depth++
+		case tokens[i].IsSymbol(")"):
+			depth--
+		case depth == 0 && tokens[i].IsWord("LOCAL") && tokens[i-1].IsWord(keyword) && isOneOfWords(tokens[i+1], formats):
+			return localFileStatement{query: query, tokens: tokens, local: i}, true
+		}
+	}
+	return localFileStatement{}, false
+}
+
+// format returns the format of the local files, i.e. CSV or FBV.
+func (s localFileStatement) format() string {
+	return strings.ToUpper(s.tokens[s.local+1].Text)
+}
+
+// replaceLocalFiles replaces the local files with a single file for each proxy.
+func (s localFileStatement) replaceLocalFiles(proxyLocations []string, fileName string) string {
+	var result strings.Builder
+	formatToken := s.tokens[s.local+1]
+	result.WriteString(s.query[:s.tokens[s.local].Start])
+	result.WriteString(fmt.Sprintf("%s AT %s", s.format(), proxyLocations[0]))
+	position := formatToken.End()
+
+	files := fmt.Sprintf("FILE '%s' ", fileName)
+	for _, proxyLocation := range proxyLocations[1:] {
+		files += fmt.Sprintf("AT %s FILE '%s' ", proxyLocation, fileName)
+	}
+	for index, clause := range fileClauses(s.tokens[s.local+2:]) {
+		result.WriteString(s.query[position:clause.keyword.Start])
+		if index == 0 {
+			result.WriteString(files)
+		}
+		position = clause.path.End()
+		// A single space after the file clause is part of the replacement
+		if strings.HasPrefix(s.query[position:], " ") {
+			position++
+		}
+	}
+	result.WriteString(s.query[position:])
+	return result.String()
+}
+
+// fileClause is a FILE clause of an IMPORT or EXPORT statement.
+type fileClause struct {
+	keyword Token
+	path    Token
+}
+
+func fileClauses(tokens []Token) []fileClause {
+	var clauses []fileClause
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].IsWord("FILE") && isQuoted(tokens[i+1]) {
+			clauses = append(clauses, fileClause{keyword: tokens[i], path: tokens[i+1]})
 		}
 	}
+	return clauses
+}
 
-	proxyURL := fmt.Sprintf("http://%s:%d", host, port)
-	updatedImport := fmt.Sprintf("CSV AT '%s'", proxyURL)
-	var importQueryRegex = regexp.MustCompile(`(?i)(LOCAL CSV)`)
+func isQuoted(token Token) bool {
+	return token.Type == TokenString || token.Type == TokenQuotedIdentifier
+}
 
-	return string(importQueryRegex.ReplaceAll([]byte(query), []byte(updatedImport)))
+func isOneOfWords(token Token, words []string) bool {
+	for _, word := range words {
+		if token.IsWord(word) {
+			return true
+		}
+	}
+	return false
 }
 
 func ResolveHosts(h string) ([]string, error) {
@@ -1,7 +1,6 @@
 package utils
 
 import (
-	"database/sql/driver"
 	"fmt"
 	"strings"
 	"testing"
@@ -11,20 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestNamedValuesToValues(t *testing.T) {
-	namedValues := []driver.NamedValue{{Name: ""}, {Name: ""}}
-	values, err := NamedValuesToValues(namedValues)
-	assert.Equal(t, []driver.Value{driver.Value(nil), driver.Value(nil)}, values)
-	assert.NoError(t, err)
-}
-
-func TestNamedValuesToValuesInvalidName(t *testing.T) {
-	namedValues := []driver.NamedValue{{Name: "some name"}}
-	values, err := NamedValuesToValues(namedValues)
-	assert.Nil(t, values)
-	assert.EqualError(t, err, "E-EGOD-7: named parameters not supported")
-}
-
 func TestIsImportQuery(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -36,10 +21,16 @@ func TestIsImportQuery(t *testing.T) {
 		{name: "with brackets", query: "IMPORT(something) INTO SCHEMA.TABLE FROM LOCAL CSV FILE '/path/to/filename.csv'", expectedResult: true},
 		{name: "lower case", query: "import into schema.table from local csv file '/path/to/filename.csv'", expectedResult: true},
 		{name: "with additional whitespace", query: " IMPORT \t INTO SCHEMA.TABLE\n\tFROM  LOCAL  CSV  FILE  '/path/to/filename.csv'", expectedResult: true},
-		{name: "FBV not supported", query: "IMPORT INTO SCHEMA.TABLE FROM LOCAL FBV FILE '/path/to/filename.fbf'", expectedResult: false},
+		{name: "FBV", query: "IMPORT INTO SCHEMA.TABLE FROM LOCAL FBV FILE '/path/to/filename.fbf'", expectedResult: true},
+		{name: "FBV lower case", query: "import into schema.table from local fbv file '/path/to/filename.fbv' (SIZE=8, SIZE=4)", expectedResult: true},
+		{name: "other format not supported", query: "IMPORT INTO SCHEMA.TABLE FROM LOCAL XML FILE '/path/to/filename.xml'", expectedResult: false},
 		{name: "select query unsupported", query: "select * from schema.table", expectedResult: false},
 		{name: "import in string with placeholders", query: "insert into table1 values ('import into {{dest.schema}}.{{dest.table}} ) from local csv file ''{{file.path}}'' ');", expectedResult: false},
 		{name: "import in string", query: "insert into table1 values ('import into schema.table from local csv file ''/path/to/filename.csv''');", expectedResult: false},
+		{name: "with leading comment", query: "-- load data\nIMPORT INTO t FROM LOCAL CSV FILE 'a.csv'", expectedResult: true},
+		{name: "with comment between keywords", query: "IMPORT INTO t FROM /* local */ LOCAL CSV FILE 'a.csv'", expectedResult: true},
+		{name: "commented out", query: "-- IMPORT INTO t FROM LOCAL CSV FILE 'a.csv'\nSELECT 1", expectedResult: false},
+		{name: "local csv in subselect string", query: "IMPORT INTO t FROM CSV AT 'http://host' FILE 'from local csv.csv'", expectedResult: false},
 		{name: "import in string with schema", query: "insert into schema.tab1 values ('IMPORT into schema.table FROM LOCAL CSV file ''/path/to/filename.csv'';')", expectedResult: false},
 	}
 	for _, test := range tests {
@@ -90,6 +81,12 @@ func TestUpdateImportQuery(t *testing.T) {
 		{name: "with options",
 			query:    "IMPORT INTO table_1 FROM LOCAL CSV USER 'agent_007' IDENTIFIED BY 'secret' FILE 'tab1_part1.csv' FILE 'tab1_part2.csv' COLUMN SEPARATOR = ';' SKIP = 5;",
 			expected: "IMPORT INTO table_1 FROM CSV AT 'http://127.0.0.1:4333' USER 'agent_007' IDENTIFIED BY 'secret' FILE 'data.csv' COLUMN SEPARATOR = ';' SKIP = 5;"},
+		{name: "fbv",
+			query:    "IMPORT INTO table_1 FROM LOCAL FBV FILE 'tab1_part1.fbv' FILE 'tab1_part2.fbv' (SIZE=8 PADDING='+' ALIGN=RIGHT, SIZE=4) ROW SEPARATOR = 'CRLF'",
+			expected: "IMPORT INTO table_1 FROM FBV AT 'http://127.0.0.1:4333' FILE 'data.fbv' (SIZE=8 PADDING='+' ALIGN=RIGHT, SIZE=4) ROW SEPARATOR = 'CRLF'"},
+		{name: "fbv lower case with whitespace",
+			query:    "import into table_1 from local\n fbv file 'tab1.fbv'",
+			expected: "import into table_1 from FBV AT 'http://127.0.0.1:4333' FILE 'data.fbv' "},
 		{name: "with newline",
 			query:    "IMPORT INTO table_1\nFROM LOCAL CSV USER 'agent_007' IDENTIFIED BY 'secret' FILE 'tab1_part1.csv' FILE 'tab1_part2.csv' COLUMN SEPARATOR = ';'\r\nSKIP = 5;",
 			expected: "IMPORT INTO table_1\nFROM CSV AT 'http://127.0.0.1:4333' USER 'agent_007' IDENTIFIED BY 'secret' FILE 'data.csv' COLUMN SEPARATOR = ';'\r\nSKIP = 5;"},
@@ -102,6 +99,122 @@ func TestUpdateImportQuery(t *testing.T) {
 	}
 }
 
+func TestUpdateParallelImportQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "non import query",
+			query:    "select * from table",
+			expected: "select * from table"},
+		{name: "single file",
+			query:    "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv'",
+			expected: "IMPORT into table FROM CSV AT 'http://10.0.0.1:4333' FILE 'data.csv' AT 'http://10.0.0.2:4334' FILE 'data.csv' "},
+		{name: "multiple files",
+			query:    "IMPORT into table FROM LOCAL CSV file 'data.csv' file '/path/to/filename2.csv' COLUMN SEPARATOR = ';'",
+			expected: "IMPORT into table FROM CSV AT 'http://10.0.0.1:4333' FILE 'data.csv' AT 'http://10.0.0.2:4334' FILE 'data.csv' COLUMN SEPARATOR = ';'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updatedQuery := UpdateParallelImportQuery(test.query, []string{"'http://10.0.0.1:4333'", "'http://10.0.0.2:4334'"}, false)
+			assert.Equal(t, test.expected, updatedQuery)
+		})
+	}
+}
+
+func TestProxyLocation(t *testing.T) {
+	assert.Equal(t, "'http://10.0.0.1:4333'", ProxyLocation("10.0.0.1", 4333, ""))
+	assert.Equal(t, "'https://10.0.0.1:4333' PUBLIC KEY 'sha256//abc='", ProxyLocation("10.0.0.1", 4333, "sha256//abc="))
+}
+
+func TestUpdateImportQueryWithEncryptedProxy(t *testing.T) {
+	updatedQuery := UpdateParallelImportQuery("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' COLUMN SEPARATOR = ';'",
+		[]string{ProxyLocation("10.0.0.1", 4333, "sha256//abc=")}, false)
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'https://10.0.0.1:4333' PUBLIC KEY 'sha256//abc=' FILE 'data.csv' COLUMN SEPARATOR = ';'", updatedQuery)
+}
+
+func TestUpdateImportQueryWithCompression(t *testing.T) {
+	updatedQuery := UpdateParallelImportQuery("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv.gz'",
+		[]string{"'http://10.0.0.1:4333'", "'http://10.0.0.2:4334'"}, true)
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4333' FILE 'data.csv.gz' AT 'http://10.0.0.2:4334' FILE 'data.csv.gz' ", updatedQuery)
+}
+
+func TestUpdateImportQueryWithCompressedFBV(t *testing.T) {
+	updatedQuery := UpdateParallelImportQuery("IMPORT INTO t FROM LOCAL FBV FILE 'a.fbv.gz' (SIZE=8)",
+		[]string{"'http://10.0.0.1:4333'"}, true)
+	assert.Equal(t, "IMPORT INTO t FROM FBV AT 'http://10.0.0.1:4333' FILE 'data.fbv.gz' (SIZE=8)", updatedQuery)
+}
+
+func TestIsCompressedFile(t *testing.T) {
+	assert.True(t, IsCompressedFile("/path/to/data.csv.gz"))
+	assert.True(t, IsCompressedFile("DATA.CSV.GZ"))
+	assert.False(t, IsCompressedFile("/path/to/data.csv"))
+	assert.False(t, IsCompressedFile("data.gz.csv"))
+}
+
+func TestHasSkipOption(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedResult bool
+	}{
+		{name: "skip", query: "IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' SKIP = 1", expectedResult: true},
+		{name: "lower case without whitespace", query: "import into t from local csv file 'a.csv' skip=1", expectedResult: true},
+		{name: "without skip", query: "IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' COLUMN SEPARATOR = ';'", expectedResult: false},
+		{name: "skip in file name", query: "IMPORT INTO t FROM LOCAL CSV FILE 'skip.csv'", expectedResult: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, HasSkipOption(test.query))
+		})
+	}
+}
+
+func TestIsExportQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedResult bool
+	}{
+		{name: "table", query: "EXPORT schema.table INTO LOCAL CSV FILE '/path/to/filename.csv'", expectedResult: true},
+		{name: "lower case", query: "export schema.table into local csv file '/path/to/filename.csv'", expectedResult: true},
+		{name: "subquery", query: "EXPORT (SELECT * FROM t) INTO LOCAL CSV FILE 'filename.csv' COLUMN SEPARATOR = ';'", expectedResult: true},
+		{name: "with additional whitespace", query: " EXPORT \t schema.table\n\tINTO  LOCAL  CSV  FILE  'filename.csv'", expectedResult: true},
+		{name: "remote export", query: "EXPORT schema.table INTO CSV AT 'http://host' FILE 'filename.csv'", expectedResult: false},
+		{name: "local import", query: "IMPORT INTO SCHEMA.TABLE FROM LOCAL CSV FILE '/path/to/filename.csv'", expectedResult: false},
+		{name: "export in string", query: "insert into table1 values ('export schema.table into local csv file ''/path/to/filename.csv''');", expectedResult: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedResult, IsExportQuery(test.query))
+		})
+	}
+}
+
+func TestUpdateExportQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "non export query",
+			query:    "select * from table",
+			expected: "select * from table"},
+		{name: "single file",
+			query:    "EXPORT table INTO LOCAL CSV FILE '/path/to/filename.csv'",
+			expected: "EXPORT table INTO CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' "},
+		{name: "with options",
+			query:    "EXPORT (SELECT * FROM t) INTO LOCAL CSV FILE 'filename.csv' COLUMN SEPARATOR = ';' WITH COLUMN NAMES",
+			expected: "EXPORT (SELECT * FROM t) INTO CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' COLUMN SEPARATOR = ';' WITH COLUMN NAMES"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, UpdateExportQuery(test.query, "'http://127.0.0.1:4333'"))
+		})
+	}
+}
+
 func TestGetFilePaths(t *testing.T) {
 	quotes := []struct {
 		name  string
@@ -145,6 +258,57 @@ func TestGetFilePaths(t *testing.T) {
 	}
 }
 
+func TestGetFilePathsFBV(t *testing.T) {
+	foundPaths, err := GetFilePaths("IMPORT INTO t FROM LOCAL FBV FILE '/path/to/a.fbv' FILE 'b.fbv' (SIZE=8 PADDING='+' ALIGN=RIGHT, SIZE=4)")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/path/to/a.fbv", "b.fbv"}, foundPaths)
+}
+
+func TestGetFilePathsWithSpecialCharacters(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{name: "unicode directory with spaces", query: "IMPORT INTO t FROM LOCAL CSV FILE '/home/jürgen/Äpfel und Birnen/data.csv'", expected: []string{"/home/jürgen/Äpfel und Birnen/data.csv"}},
+		{name: "parentheses", query: "IMPORT INTO t FROM LOCAL CSV FILE 'C:\\Program Files (x86)\\data.csv'", expected: []string{"C:\\Program Files (x86)\\data.csv"}},
+		{name: "escaped quote", query: "IMPORT INTO t FROM LOCAL CSV FILE 'it''s.csv'", expected: []string{"it's.csv"}},
+		{name: "FILE in string literal", query: "IMPORT INTO t FROM LOCAL CSV FILE 'a FILE ''b.csv''.csv'", expected: []string{"a FILE 'b.csv'.csv"}},
+		{name: "FILE in comment", query: "IMPORT INTO t FROM LOCAL CSV /* FILE 'old.csv' */ FILE 'a.csv' -- FILE 'b.csv'", expected: []string{"a.csv"}},
+		{name: "comment between FILE and path", query: "IMPORT INTO t FROM LOCAL CSV FILE /* path */ 'a.csv'", expected: []string{"a.csv"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := GetFilePaths(test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, paths)
+		})
+	}
+}
+
+func TestUpdateImportQueryIgnoresCommentsAndLiterals(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "FILE in comment",
+			query:    "IMPORT INTO t FROM LOCAL CSV /* FILE 'old.csv' */ FILE 'a.csv' -- FILE 'b.csv'",
+			expected: "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' /* FILE 'old.csv' */ FILE 'data.csv' -- FILE 'b.csv'"},
+		{name: "local csv in string literal",
+			query:    "IMPORT INTO t FROM LOCAL CSV FILE 'local csv (1).csv' COLUMN DELIMITER = 'local csv'",
+			expected: "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' COLUMN DELIMITER = 'local csv'"},
+		{name: "unicode path with escaped quote",
+			query:    "IMPORT INTO t FROM LOCAL CSV FILE '/tmp/Grüße/it''s.csv' FILE '/tmp/b.csv'",
+			expected: "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' "},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, UpdateImportQuery(test.query, "127.0.0.1", 4333))
+		})
+	}
+}
+
 func TestGetRowSeparatorCompleteQuery(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -160,8 +324,12 @@ func TestGetRowSeparatorCompleteQuery(t *testing.T) {
 		{name: "missing expression returns default", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv'", expected: "\n"},
 		{name: "trailing text", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW SEPARATOR = 'CRLF' trailing text", expected: "\r\n"},
 		{name: "multiple spaces", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW SEPARATOR \t = \t 'CRLF';", expected: "\r\n"},
-		{name: "no spaces returns default", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW SEPARATOR='CRLF';", expected: "\n"},
+		{name: "no spaces", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW SEPARATOR='CRLF';", expected: "\r\n"},
+		{name: "with comment", query: "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW /* comment */ SEPARATOR = 'CRLF'", expected: "\r\n"},
+		{name: "in string literal returns default", query: "IMPORT into table FROM LOCAL CSV file 'ROW SEPARATOR = ''CRLF''.csv'", expected: "\n"},
+		{name: "in comment returns default", query: "IMPORT into table FROM LOCAL CSV file 'a.csv' -- ROW SEPARATOR = 'CRLF'", expected: "\n"},
 		{name: "with line breaks", query: "IMPORT into table\nFROM LOCAL CSV file '/path/to/filename.csv'\nROW\r\nSEPARATOR = 'CRLF';", expected: "\r\n"},
+		{name: "FBV", query: "IMPORT into table FROM LOCAL FBV file '/path/to/filename.fbv' (SIZE=8, SIZE=4) ROW SEPARATOR = 'CRLF'", expected: "\r\n"},
 		{name: "unknown query returns default", query: "select * from table", expected: "\n"},
 	}
 	for _, test := range tests {
@@ -171,6 +339,27 @@ func TestGetRowSeparatorCompleteQuery(t *testing.T) {
 	}
 }
 
+func TestGetColumnDelimiter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "default", query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", expected: `"`},
+		{name: "custom", query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' COLUMN DELIMITER = '|'", expected: "|"},
+		{name: "single quote", query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' COLUMN DELIMITER = ''''", expected: "'"},
+		{name: "empty", query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' COLUMN DELIMITER = ''", expected: ""},
+		{name: "column separator is ignored", query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' COLUMN SEPARATOR = ';'", expected: `"`},
+		{name: "in string literal returns default", query: "IMPORT INTO t FROM LOCAL CSV FILE 'COLUMN DELIMITER = ''|''.csv'", expected: `"`},
+		{name: "FBV has no delimiter", query: "IMPORT INTO t FROM LOCAL FBV FILE 'data.fbv' (SIZE=8)", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GetColumnDelimiter(test.query))
+		})
+	}
+}
+
 func TestGetRowSeparator(t *testing.T) {
 	tests := []struct {
 		name      string
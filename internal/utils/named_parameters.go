@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// NamedParameters describes the parameter placeholders of a query after named placeholders were replaced.
+type NamedParameters struct {
+	// Count is the total number of placeholders in the query, including positional placeholders.
+	Count int
+	// Indices maps the name of each named placeholder to its positions in the query.
+	Indices map[string][]int
+}
+
+// ReplaceNamedParameters replaces named placeholders like `:name` and `@name` with positional placeholders `?`.
+// Placeholders in string literals, quoted identifiers and comments are not modified.
+func ReplaceNamedParameters(query string) (string, NamedParameters) {
+	parameters := NamedParameters{Indices: make(map[string][]int)}
+	tokens := Tokenize(query)
+	var result strings.Builder
+	result.Grow(len(query))
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i].IsSymbol("?"):
+			parameters.Count++
+		case isNamedPlaceholder(tokens, i):
+			name := tokens[i+1].Text
+			parameters.Indices[name] = append(parameters.Indices[name], parameters.Count)
+			parameters.Count++
+			result.WriteByte('?')
+			i++
+			continue
+		}
+		result.WriteString(tokens[i].Text)
+	}
+	return result.String(), parameters
+}
+
+// Bind converts the given arguments to positional values.
+// Arguments without names are returned in the given order, named arguments are assigned to their placeholders.
+func (p NamedParameters) Bind(namedValues []driver.NamedValue) ([]driver.Value, error) {
+	if !HasNamedValues(namedValues) {
+		return PositionalValues(namedValues), nil
+	}
+	values := make([]driver.Value, p.Count)
+	bound := make([]bool, p.Count)
+	for _, namedValue := range namedValues {
+		if namedValue.Name == "" {
+			return nil, errors.ErrMixedNamedAndPositionalParameters
+		}
+		indices, ok := p.Indices[namedValue.Name]
+		if !ok {
+			return nil, errors.NewErrUnknownNamedParameter(namedValue.Name)
+		}
+		for _, index := range indices {
+			values[index] = namedValue.Value
+			bound[index] = true
+		}
+	}
+	for index := range bound {
+		if !bound[index] {
+			return nil, errors.NewErrMissingNamedParameter(p.nameAt(index))
+		}
+	}
+	return values, nil
+}
+
+func (p NamedParameters) nameAt(index int) string {
+	for name, indices := range p.Indices {
+		for _, i := range indices {
+			if i == index {
+				return name
+			}
+		}
+	}
+	// Positional placeholders can't be bound by name
+	return "?"
+}
+
+// PositionalValues returns the values of the given arguments in their order, ignoring their names.
+func PositionalValues(namedValues []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(namedValues))
+	for index, namedValue := range namedValues {
+		values[index] = namedValue.Value
+	}
+	return values
+}
+
+// HasNamedValues returns true if any of the given arguments has a name, i.e. was passed with `sql.Named()`.
+func HasNamedValues(namedValues []driver.NamedValue) bool {
+	for _, namedValue := range namedValues {
+		if namedValue.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamedPlaceholder returns true if a named placeholder starts at the given token.
+// The prefix must not follow a word to avoid matching e.g. method calls like `obj:method()` in scripts.
+func isNamedPlaceholder(tokens []Token, i int) bool {
+	if !tokens[i].IsSymbol(":") && !tokens[i].IsSymbol("@") {
+		return false
+	}
+	if i+1 >= len(tokens) || tokens[i+1].Type != TokenWord || !isIdentifierStart(tokens[i+1].Text) {
+		return false
+	}
+	return i == 0 || !(tokens[i-1].Type == TokenWord || tokens[i-1].IsSymbol(":"))
+}
+
+func isIdentifierStart(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return r == '_' || unicode.IsLetter(r)
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceNamedParameters(t *testing.T) {
+	tests := []struct {
+		name               string
+		query              string
+		expectedQuery      string
+		expectedParameters NamedParameters
+	}{
+		{name: "no parameters", query: "select * from t", expectedQuery: "select * from t",
+			expectedParameters: NamedParameters{Count: 0, Indices: map[string][]int{}}},
+		{name: "positional", query: "select * from t where a = ? and b = ?", expectedQuery: "select * from t where a = ? and b = ?",
+			expectedParameters: NamedParameters{Count: 2, Indices: map[string][]int{}}},
+		{name: "colon prefix", query: "select * from t where a = :a and b=:b_2", expectedQuery: "select * from t where a = ? and b=?",
+			expectedParameters: NamedParameters{Count: 2, Indices: map[string][]int{"a": {0}, "b_2": {1}}}},
+		{name: "at prefix", query: "insert into t values (@a,@b)", expectedQuery: "insert into t values (?,?)",
+			expectedParameters: NamedParameters{Count: 2, Indices: map[string][]int{"a": {0}, "b": {1}}}},
+		{name: "repeated name", query: "select :a, ?, :a", expectedQuery: "select ?, ?, ?",
+			expectedParameters: NamedParameters{Count: 3, Indices: map[string][]int{"a": {0, 2}}}},
+		{name: "string literal", query: "select ':a', 'it''s :b', :c", expectedQuery: "select ':a', 'it''s :b', ?",
+			expectedParameters: NamedParameters{Count: 1, Indices: map[string][]int{"c": {0}}}},
+		{name: "quoted identifier", query: `select "a:b" from t where a = @a`, expectedQuery: `select "a:b" from t where a = ?`,
+			expectedParameters: NamedParameters{Count: 1, Indices: map[string][]int{"a": {0}}}},
+		{name: "line comment", query: "select :a -- :b ?\nfrom t", expectedQuery: "select ? -- :b ?\nfrom t",
+			expectedParameters: NamedParameters{Count: 1, Indices: map[string][]int{"a": {0}}}},
+		{name: "block comment", query: "select /* :b ? */ :a", expectedQuery: "select /* :b ? */ ?",
+			expectedParameters: NamedParameters{Count: 1, Indices: map[string][]int{"a": {0}}}},
+		{name: "unterminated string", query: "select :a, 'abc :b", expectedQuery: "select ?, 'abc :b",
+			expectedParameters: NamedParameters{Count: 1, Indices: map[string][]int{"a": {0}}}},
+		{name: "method call", query: "obj:method()", expectedQuery: "obj:method()",
+			expectedParameters: NamedParameters{Count: 0, Indices: map[string][]int{}}},
+		{name: "no name", query: "select : a, @1", expectedQuery: "select : a, @1",
+			expectedParameters: NamedParameters{Count: 0, Indices: map[string][]int{}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, parameters := ReplaceNamedParameters(test.query)
+			assert.Equal(t, test.expectedQuery, query)
+			assert.Equal(t, test.expectedParameters, parameters)
+		})
+	}
+}
+
+func TestBindPositionalValues(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select ?, ?")
+	values, err := parameters.Bind([]driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{"a", 2}, values)
+}
+
+func TestBindPositionalValuesToNamedParameters(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a, :b")
+	values, err := parameters.Bind([]driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{"a", "b"}, values)
+}
+
+func TestBindNamedValues(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a, :b, :a")
+	values, err := parameters.Bind([]driver.NamedValue{{Name: "b", Ordinal: 1, Value: "b"}, {Name: "a", Ordinal: 2, Value: "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{"a", "b", "a"}, values)
+}
+
+func TestBindNamedValuesUnknownName(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a")
+	values, err := parameters.Bind([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: "a"}, {Name: "c", Ordinal: 2, Value: "c"}})
+	assert.Nil(t, values)
+	assert.EqualError(t, err, "E-EGOD-33: named parameter 'c' is not used in the query")
+}
+
+func TestBindNamedValuesMissingValue(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a, :b")
+	values, err := parameters.Bind([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: "a"}})
+	assert.Nil(t, values)
+	assert.EqualError(t, err, "E-EGOD-34: no value given for parameter 'b'")
+}
+
+func TestBindNamedValuesMissingPositionalValue(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a, ?")
+	values, err := parameters.Bind([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: "a"}})
+	assert.Nil(t, values)
+	assert.EqualError(t, err, "E-EGOD-34: no value given for parameter '?'")
+}
+
+func TestBindMixedValues(t *testing.T) {
+	_, parameters := ReplaceNamedParameters("select :a, ?")
+	values, err := parameters.Bind([]driver.NamedValue{{Name: "a", Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: "b"}})
+	assert.Nil(t, values)
+	assert.EqualError(t, err, "E-EGOD-35: named and positional parameters can't be mixed")
+}
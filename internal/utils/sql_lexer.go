@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenType is the type of a token of an SQL statement.
+type TokenType int
+
+const (
+	// TokenWord is a keyword, an unquoted identifier or a number.
+	TokenWord TokenType = iota
+	// TokenString is a string literal in single quotes.
+	TokenString
+	// TokenQuotedIdentifier is an identifier in double quotes.
+	TokenQuotedIdentifier
+	// TokenComment is a line comment starting with -- or a block comment /* ... */.
+	TokenComment
+	// TokenWhitespace is a sequence of whitespace characters.
+	TokenWhitespace
+	// TokenSymbol is any other single character, e.g. an operator, a parenthesis or a placeholder.
+	TokenSymbol
+)
+
+// Token is a part of an SQL statement.
+type Token struct {
+	Type TokenType
+	// Text is the original text of the token including quotes and comment markers.
+	Text string
+	// Start is the byte offset of the token in the statement.
+	Start int
+}
+
+// End returns the byte offset after the token in the statement.
+func (t Token) End() int {
+	return t.Start + len(t.Text)
+}
+
+// Value returns the content of a string literal or quoted identifier without quotes and with unescaped quotes.
+// For all other tokens Value returns the original text.
+func (t Token) Value() string {
+	switch t.Type {
+	case TokenString:
+		return unquote(t.Text, "'")
+	case TokenQuotedIdentifier:
+		return unquote(t.Text, `"`)
+	default:
+		return t.Text
+	}
+}
+
+// IsWord returns true if the token is the given keyword or unquoted identifier, ignoring case.
+func (t Token) IsWord(word string) bool {
+	return t.Type == TokenWord && strings.EqualFold(t.Text, word)
+}
+
+// IsSymbol returns true if the token is the given symbol.
+func (t Token) IsSymbol(symbol string) bool {
+	return t.Type == TokenSymbol && t.Text == symbol
+}
+
+// Tokenize splits an SQL statement into tokens. It understands Exasol's string literals and quoted identifiers
+// with doubled quotes as escape sequence as well as line and block comments.
+// Concatenating the text of all tokens returns the original statement.
+func Tokenize(query string) []Token {
+	var tokens []Token
+	for start := 0; start < len(query); {
+		tokenType, end := nextToken(query, start)
+		tokens = append(tokens, Token{Type: tokenType, Text: query[start:end], Start: start})
+		start = end
+	}
+	return tokens
+}
+
+func nextToken(query string, start int) (TokenType, int) {
+	r, size := utf8.DecodeRuneInString(query[start:])
+	switch {
+	case r == '\'':
+		return TokenString, skipQuoted(query, start, '\'')
+	case r == '"':
+		return TokenQuotedIdentifier, skipQuoted(query, start, '"')
+	case strings.HasPrefix(query[start:], "--"):
+		return TokenComment, skipLine(query, start)
+	case strings.HasPrefix(query[start:], "/*"):
+		return TokenComment, skipUntil(query, start+2, "*/")
+	case unicode.IsSpace(r):
+		return TokenWhitespace, skipWhile(query, start, unicode.IsSpace)
+	case isWordRune(r):
+		return TokenWord, skipWhile(query, start, isWordRune)
+	default:
+		return TokenSymbol, start + size
+	}
+}
+
+// significantTokens returns the tokens without whitespace and comments.
+func significantTokens(tokens []Token) []Token {
+	result := make([]Token, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Type != TokenWhitespace && token.Type != TokenComment {
+			result = append(result, token)
+		}
+	}
+	return result
+}
+
+func unquote(text string, quote string) string {
+	text = strings.TrimPrefix(text, quote)
+	if len(text) > 0 && strings.HasSuffix(text, quote) {
+		text = text[:len(text)-1]
+	}
+	return strings.ReplaceAll(text, quote+quote, quote)
+}
+
+// skipQuoted returns the position after the quoted text starting at the given position.
+// Doubled quotes inside the text are escaped quotes.
+func skipQuoted(query string, start int, quote byte) int {
+	for i := start + 1; i < len(query); i++ {
+		if query[i] == quote {
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// skipUntil returns the position after the given terminator or the end of the query.
+func skipUntil(query string, start int, terminator string) int {
+	end := strings.Index(query[start:], terminator)
+	if end < 0 {
+		return len(query)
+	}
+	return start + end + len(terminator)
+}
+
+// skipLine returns the position of the next line break or the end of the query.
+func skipLine(query string, start int) int {
+	end := strings.IndexByte(query[start:], '\n')
+	if end < 0 {
+		return len(query)
+	}
+	return start + end
+}
+
+func skipWhile(query string, start int, predicate func(rune) bool) int {
+	for i, r := range query[start:] {
+		if !predicate(r) {
+			return start + i
+		}
+	}
+	return len(query)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
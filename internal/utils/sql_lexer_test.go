@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize("SELECT 'it''s', \"Col\"\"1\" -- comment\nFROM /* block */ tab1;")
+	assert.Equal(t, []Token{
+		{Type: TokenWord, Text: "SELECT", Start: 0},
+		{Type: TokenWhitespace, Text: " ", Start: 6},
+		{Type: TokenString, Text: "'it''s'", Start: 7},
+		{Type: TokenSymbol, Text: ",", Start: 14},
+		{Type: TokenWhitespace, Text: " ", Start: 15},
+		{Type: TokenQuotedIdentifier, Text: `"Col""1"`, Start: 16},
+		{Type: TokenWhitespace, Text: " ", Start: 24},
+		{Type: TokenComment, Text: "-- comment", Start: 25},
+		{Type: TokenWhitespace, Text: "\n", Start: 35},
+		{Type: TokenWord, Text: "FROM", Start: 36},
+		{Type: TokenWhitespace, Text: " ", Start: 40},
+		{Type: TokenComment, Text: "/* block */", Start: 41},
+		{Type: TokenWhitespace, Text: " ", Start: 52},
+		{Type: TokenWord, Text: "tab1", Start: 53},
+		{Type: TokenSymbol, Text: ";", Start: 57},
+	}, tokens)
+}
+
+func TestTokenizeKeepsOriginalText(t *testing.T) {
+	queries := []string{
+		"",
+		"IMPORT INTO t FROM LOCAL CSV FILE '/tmp/Grüße (1)/data.csv'",
+		"SELECT 'unterminated",
+		"SELECT /* unterminated",
+		"SELECT ä, ß FROM \"ünïcödé\" WHERE a<>b -- end",
+	}
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			var text strings.Builder
+			for _, token := range Tokenize(query) {
+				assert.Equal(t, query[token.Start:token.End()], token.Text)
+				text.WriteString(token.Text)
+			}
+			assert.Equal(t, query, text.String())
+		})
+	}
+}
+
+func TestTokenValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    Token
+		expected string
+	}{
+		{name: "string", token: Token{Type: TokenString, Text: "'a.csv'"}, expected: "a.csv"},
+		{name: "escaped quote", token: Token{Type: TokenString, Text: "'it''s.csv'"}, expected: "it's.csv"},
+		{name: "unterminated string", token: Token{Type: TokenString, Text: "'a.csv"}, expected: "a.csv"},
+		{name: "quoted identifier", token: Token{Type: TokenQuotedIdentifier, Text: `"a""b"`}, expected: `a"b`},
+		{name: "word", token: Token{Type: TokenWord, Text: "FILE"}, expected: "FILE"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.token.Value())
+		})
+	}
+}
+
+func TestTokenIsWord(t *testing.T) {
+	assert.True(t, Token{Type: TokenWord, Text: "local"}.IsWord("LOCAL"))
+	assert.False(t, Token{Type: TokenString, Text: "LOCAL"}.IsWord("LOCAL"))
+	assert.False(t, Token{Type: TokenWord, Text: "LOCALS"}.IsWord("LOCAL"))
+}
@@ -3,12 +3,16 @@ package itest_test
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"os"
 	"os/user"
+	"reflect"
 	"regexp"
 	"runtime"
 	"strings"
@@ -16,8 +20,11 @@ import (
 	"time"
 
 	"github.com/exasol/exasol-driver-go"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
 	"github.com/exasol/exasol-driver-go/pkg/dsn"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/integrationTesting"
+	"github.com/exasol/exasol-driver-go/pkg/types"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
@@ -160,6 +167,69 @@ func (suite *IntegrationTestSuite) TestExecAndQuery() {
 	suite.assertSingleValueResult(rows, "15")
 }
 
+func (suite *IntegrationTestSuite) TestQueryBatchReturnsAllResults() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_BATCH"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	conn, err := database.Conn(suite.ctx)
+	suite.NoError(err)
+	defer conn.Close()
+	var results [][]driver.Value
+	err = conn.Raw(func(driverConn any) error {
+		rows, err := driverConn.(*connection.Connection).QueryBatch(suite.ctx, []string{
+			"CREATE TABLE " + schemaName + ".TEST_TABLE(x INT)",
+			"INSERT INTO " + schemaName + ".TEST_TABLE VALUES (1), (2)",
+			"SELECT x FROM " + schemaName + ".TEST_TABLE ORDER BY x",
+		})
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for {
+			for {
+				row := make([]driver.Value, 1)
+				if rows.Next(row) != nil {
+					break
+				}
+				results = append(results, row)
+			}
+			if err := rows.(driver.RowsNextResultSet).NextResultSet(); err != nil {
+				return nil
+			}
+		}
+	})
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{{int64(0)}, {int64(2)}, {int64(1)}, {int64(2)}}, results)
+}
+
+func (suite *IntegrationTestSuite) TestExecBatchReportsFailingStatement() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_EXEC_BATCH"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	conn, err := database.Conn(suite.ctx)
+	suite.NoError(err)
+	defer conn.Close()
+	var results []driver.Result
+	err = conn.Raw(func(driverConn any) error {
+		var err error
+		results, err = driverConn.(*connection.Connection).ExecBatch(suite.ctx, []string{
+			"CREATE TABLE " + schemaName + ".TEST_TABLE(x INT)",
+			"INSERT INTO " + schemaName + ".TEST_TABLE VALUES (1), (2)",
+			"INSERT INTO " + schemaName + ".MISSING_TABLE VALUES (1)",
+		})
+		return err
+	})
+	var batchErr errors.BatchError
+	suite.Require().ErrorAs(err, &batchErr)
+	suite.Equal(2, batchErr.Index)
+	suite.Require().Len(results, 2)
+	rowsAffected, err := results[1].RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(2), rowsAffected)
+}
+
 func (suite *IntegrationTestSuite) TestFetch() {
 	database := suite.openConnection(suite.createDefaultConfig().FetchSize(20))
 	schemaName := "TEST_SCHEMA_FETCH"
@@ -254,6 +324,12 @@ var dereferenceInt32 = func(v any) any { return *(v.(*int32)) }
 var dereferenceInt64 = func(v any) any { return *(v.(*int64)) }
 var dereferenceInt = func(v any) any { return *(v.(*int)) }
 var dereferenceBool = func(v any) any { return *(v.(*bool)) }
+var dereferenceDuration = func(v any) any { return v.(*types.DayToSecondInterval).Duration() }
+var dereferenceDayToSecondInterval = func(v any) any { return v.(*types.DayToSecondInterval).String() }
+var dereferenceYearMonthInterval = func(v any) any { return *(v.(*types.YearMonthInterval)) }
+var dereferenceGeometry = func(v any) any { return *(v.(*types.Geometry)) }
+var dereferenceGeometryString = func(v any) any { return v.(*types.Geometry).String() }
+var dereferenceTime = func(v any) any { return v.(*time.Time).Format("2006-01-02 15:04:05.000000") }
 
 func (suite *IntegrationTestSuite) TestQueryDataTypesCast() {
 	database := suite.openConnection(suite.createDefaultConfig())
@@ -278,6 +354,9 @@ func (suite *IntegrationTestSuite) TestQueryDataTypesCast() {
 		{"min int64", fmt.Sprintf("%d", math.MinInt64), "DECIMAL(36,0)", new(int64), int64(math.MinInt64), dereferenceInt64},
 		{"decimal to float64", "2.2", "DECIMAL(18,2)", new(float64), 2.2, dereferenceFloat64},
 		{"decimal to string", "2.2", "DECIMAL(18,2)", new(string), "2.2", dereferenceString},
+		{"decimal above 2^53 to int64", "9007199254740993", "DECIMAL(18,0)", new(int64), int64(9007199254740993), dereferenceInt64},
+		{"large decimal to string", "12345678901234567890123456789012345678", "DECIMAL(38,0)", new(string), "12345678901234567890123456789012345678", dereferenceString},
+		{"decimal with scale to string", "1234567890123456.78", "DECIMAL(18,2)", new(string), "1234567890123456.78", dereferenceString},
 
 		{"double to float64", "3.3", "DOUBLE PRECISION", new(float64), 3.3, dereferenceFloat64},
 		{"double to float64", "-3.3", "DOUBLE PRECISION", new(float64), -3.3, dereferenceFloat64},
@@ -294,8 +373,12 @@ func (suite *IntegrationTestSuite) TestQueryDataTypesCast() {
 		{"timestamp to string", "'2024-06-18 17:22:13.123456'", "TIMESTAMP", new(string), "2024-06-18 17:22:13.123000", dereferenceString},
 		{"timestamp with local time zone to string", "'2024-06-18 17:22:13.123456'", "TIMESTAMP WITH LOCAL TIME ZONE", new(string), "2024-06-18 17:22:13.123000", dereferenceString},
 		{"geometry to string", "'point(1 2)'", "GEOMETRY", new(string), "POINT (1 2)", dereferenceString},
+		{"geometry to geometry", "'point(1 2)'", "GEOMETRY", new(types.Geometry), types.Geometry{Type: types.GeometryPoint, Points: []types.Point{{X: 1, Y: 2}}}, dereferenceGeometry},
 		{"interval ytm to string", "'5-3'", "INTERVAL YEAR TO MONTH", new(string), "+05-03", dereferenceString},
+		{"interval ytm to year month interval", "'5-3'", "INTERVAL YEAR TO MONTH", new(types.YearMonthInterval), types.YearMonthInterval{Years: 5, Months: 3}, dereferenceYearMonthInterval},
 		{"interval dts to string", "'2 12:50:10.123'", "INTERVAL DAY TO SECOND", new(string), "+02 12:50:10.123", dereferenceString},
+		{"interval dts to duration", "'2 12:50:10.123'", "INTERVAL DAY TO SECOND", new(types.DayToSecondInterval), 60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, dereferenceDuration},
+		{"interval dts to day to second interval", "'-2 12:50:10.123'", "INTERVAL DAY TO SECOND", new(types.DayToSecondInterval), "-02 12:50:10.123", dereferenceDayToSecondInterval},
 		{"hashtype to string", "'550e8400-e29b-11d4-a716-446655440000'", "HASHTYPE", new(string), "550e8400e29b11d4a716446655440000", dereferenceString},
 		{"bool to bool", "true", "BOOLEAN", new(bool), true, dereferenceBool},
 		{"bool to string", "false", "BOOLEAN", new(string), "false", dereferenceString},
@@ -312,6 +395,42 @@ func (suite *IntegrationTestSuite) TestQueryDataTypesCast() {
 	}
 }
 
+func (suite *IntegrationTestSuite) TestQueryTimeValuesWithParseTime() {
+	database := suite.openConnection(suite.createDefaultConfig().ParseTime(true))
+	defer database.Close()
+
+	for i, testCase := range []struct {
+		sqlValue      string
+		sqlType       string
+		expectedValue string
+	}{
+		{"'2024-06-18'", "DATE", "2024-06-18 00:00:00.000000"},
+		{"'2024-06-18 17:22:13.123456'", "TIMESTAMP", "2024-06-18 17:22:13.123000"},
+		{"'2024-06-18 17:22:13.123456'", "TIMESTAMP WITH LOCAL TIME ZONE", "2024-06-18 17:22:13.123000"},
+	} {
+		suite.Run(fmt.Sprintf("Time Test %02d %s", i, testCase.sqlType), func() {
+			var value time.Time
+			onError(database.QueryRow(fmt.Sprintf("SELECT CAST(%s AS %s)", testCase.sqlValue, testCase.sqlType)).Scan(&value))
+			suite.Equal(testCase.expectedValue, dereferenceTime(&value))
+		})
+	}
+}
+
+func (suite *IntegrationTestSuite) TestScanNullIntervalsIntoPointers() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+
+	dayToSecond := new(types.DayToSecondInterval)
+	yearToMonth := new(types.YearMonthInterval)
+	onError(database.QueryRow("SELECT CAST(NULL AS INTERVAL DAY TO SECOND), CAST(NULL AS INTERVAL YEAR TO MONTH)").Scan(&dayToSecond, &yearToMonth))
+	suite.Nil(dayToSecond)
+	suite.Nil(yearToMonth)
+
+	var interval types.DayToSecondInterval
+	err := database.QueryRow("SELECT CAST(NULL AS INTERVAL DAY TO SECOND)").Scan(&interval)
+	suite.ErrorContains(err, "E-EGOD-47")
+}
+
 func (suite *IntegrationTestSuite) TestPreparedStatementArgsConverted() {
 	database := suite.openConnection(suite.createDefaultConfig().Autocommit(false))
 	schemaName := "DATATYPE_TEST"
@@ -372,6 +491,10 @@ func (suite *IntegrationTestSuite) TestPreparedStatementArgsConverted() {
 		float64TestCase(100000000.12, "DECIMAL(18,2)", 100000000.12),
 		float64TestCase(-100000000.12, "DECIMAL(18,2)", -100000000.12),
 
+		stringTestCase(uint64(math.MaxUint64), "DECIMAL(20,0)", "18446744073709551615"),
+		stringTestCase(new(big.Int).Lsh(big.NewInt(1), 100), "DECIMAL(36,0)", "1267650600228229401496703205376"),
+		stringTestCase(big.NewRat(1, 4), "DECIMAL(18,2)", "0.25"),
+
 		float32TestCase(1, "DECIMAL(18,0)", 1),
 		float32TestCase(-1, "DECIMAL(18,0)", -1),
 		float32TestCase(1.123, "DECIMAL(18,3)", 1.123),
@@ -390,6 +513,8 @@ func (suite *IntegrationTestSuite) TestPreparedStatementArgsConverted() {
 
 		// VARCHAR
 		stringTestCase("text", "VARCHAR(10)", "text"),
+		stringTestCase(time.Date(2024, time.June, 18, 17, 22, 13, 0, time.UTC), "VARCHAR(30)", "2024-06-18T17:22:13Z"),
+		stringTestCase(json.RawMessage(`{"a":1}`), "VARCHAR(10)", `{"a":1}`),
 		stringTestCase("text", "CHAR(10)", "text      "),
 		stringTestCase("2024-06-18", "DATE", "2024-06-18"),
 		stringTestCase(time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC), "DATE", "2024-06-18"),
@@ -398,9 +523,17 @@ func (suite *IntegrationTestSuite) TestPreparedStatementArgsConverted() {
 		stringTestCase("2024-06-18 17:22:13.123456", "TIMESTAMP WITH LOCAL TIME ZONE", "2024-06-18 17:22:13.123000"),
 		stringTestCase(time.Date(2024, time.June, 18, 17, 22, 13, 123456789, time.UTC), "TIMESTAMP WITH LOCAL TIME ZONE", "2024-06-18 17:22:13.123000"),
 		stringTestCase("point(1 2)", "GEOMETRY", "POINT (1 2)"),
+		{sqlValue: "point(1 2)", sqlType: "GEOMETRY(4326)", scanDest: new(types.Geometry), expectedValue: "POINT (1 2)", dereference: dereferenceGeometryString},
+		{sqlValue: types.Geometry{Type: types.GeometryLineString, Points: []types.Point{{X: 1, Y: 2}, {X: 3, Y: 4}}}, sqlType: "GEOMETRY", scanDest: new(types.Geometry), expectedValue: "LINESTRING (1 2, 3 4)", dereference: dereferenceGeometryString},
 		stringTestCase("5-3", "INTERVAL YEAR TO MONTH", "+05-03"),
 		stringTestCase("2 12:50:10.123", "INTERVAL DAY TO SECOND", "+02 12:50:10.123"),
+		{sqlValue: "2 12:50:10.123", sqlType: "INTERVAL DAY TO SECOND", scanDest: new(types.DayToSecondInterval), expectedValue: 60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, dereference: dereferenceDuration},
+		{sqlValue: 60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, sqlType: "INTERVAL DAY TO SECOND", scanDest: new(types.DayToSecondInterval), expectedValue: 60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, dereference: dereferenceDuration},
+		{sqlValue: types.DayToSecondInterval(60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond), sqlType: "INTERVAL DAY TO SECOND", scanDest: new(types.DayToSecondInterval), expectedValue: 60*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, dereference: dereferenceDuration},
+		stringTestCase(types.YearMonthInterval{Years: 5, Months: 3}, "INTERVAL YEAR TO MONTH", "+05-03"),
 		stringTestCase("550e8400-e29b-11d4-a716-446655440000", "HASHTYPE", "550e8400e29b11d4a716446655440000"),
+		stringTestCase([]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x11, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}, "HASHTYPE", "550e8400e29b11d4a716446655440000"),
+		boolTestCase("true", "BOOLEAN", true),
 		boolTestCase(true, "BOOLEAN", true),
 		boolTestCase(false, "BOOLEAN", false),
 	} {
@@ -440,6 +573,143 @@ func (suite *IntegrationTestSuite) TestPreparedStatementArgsConversionFails() {
 	suite.EqualError(err, "E-EGOD-30: cannot convert argument 'true' of type 'bool' to 'TIMESTAMP' type")
 }
 
+func (suite *IntegrationTestSuite) TestPreparedStatementArgsValidatedAgainstColumnType() {
+	database := suite.openConnection(suite.createDefaultConfig().Autocommit(false))
+	schemaName := "DATATYPE_TEST"
+	_, err := database.Exec("CREATE SCHEMA " + schemaName)
+	onError(err)
+	defer suite.cleanup(database, schemaName)
+
+	tableName := fmt.Sprintf("%s.TAB", schemaName)
+	_, err = database.Exec(fmt.Sprintf("CREATE TABLE %s (NAME VARCHAR(3), AMOUNT DECIMAL(4,2))", tableName))
+	onError(err)
+	stmt, err := database.Prepare(fmt.Sprintf("insert into %s values (?, ?)", tableName))
+	onError(err)
+	_, err = stmt.Exec("abcd", 1)
+	suite.EqualError(err, "E-EGOD-30: cannot convert argument 'abcd' of type 'string' to 'VARCHAR(3)' type")
+	_, err = stmt.Exec("abc", 100)
+	suite.EqualError(err, "E-EGOD-30: cannot convert argument '100' of type 'int' to 'DECIMAL(4,2)' type")
+}
+
+func (suite *IntegrationTestSuite) TestColumnTypes() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	rows, err := database.Query("SELECT CAST(1 AS DECIMAL(18,0)), CAST(1.5 AS DECIMAL(36,2)), CAST('a' AS VARCHAR(10)), " +
+		"CAST('2024-06-18 17:22:13.123' AS TIMESTAMP(3)), CAST('2024-06-18 17:22:13' AS TIMESTAMP WITH LOCAL TIME ZONE), " +
+		"CAST('point(1 2)' AS GEOMETRY(4326))")
+	onError(err)
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	onError(err)
+	for i, expected := range []struct {
+		typeName  string
+		scanType  reflect.Type
+		precision int64
+		scale     int64
+	}{
+		{"DECIMAL", reflect.TypeOf(sql.NullInt64{}), 18, 0},
+		{"DECIMAL", reflect.TypeOf(sql.NullString{}), 36, 2},
+		{"VARCHAR", reflect.TypeOf(sql.RawBytes{}), 0, 0},
+		{"TIMESTAMP", reflect.TypeOf(sql.NullString{}), 0, 3},
+		{"TIMESTAMP WITH LOCAL TIME ZONE", reflect.TypeOf(sql.NullString{}), 0, 3},
+		{"GEOMETRY", reflect.TypeOf(sql.NullString{}), 0, 0},
+	} {
+		columnType := columnTypes[i]
+		suite.Equal(expected.typeName, columnType.DatabaseTypeName(), "column %d", i)
+		suite.Equal(expected.scanType, columnType.ScanType(), "column %d", i)
+		precision, scale, _ := columnType.DecimalSize()
+		suite.Equal(expected.precision, precision, "column %d", i)
+		suite.Equal(expected.scale, scale, "column %d", i)
+		nullable, ok := columnType.Nullable()
+		suite.True(nullable, "column %d", i)
+		suite.True(ok, "column %d", i)
+	}
+	length, ok := columnTypes[2].Length()
+	suite.True(ok)
+	suite.Equal(int64(10), length)
+}
+
+func (suite *IntegrationTestSuite) TestColumnDataType() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	conn, err := database.Conn(suite.ctx)
+	onError(err)
+	defer conn.Close()
+	var dataType types.SqlQueryColumnType
+	err = conn.Raw(func(driverConn any) error {
+		rows, err := driverConn.(*connection.Connection).QueryContext(suite.ctx, "SELECT CAST('point(1 2)' AS GEOMETRY(4326))", nil)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		dataType = rows.(*connection.QueryResults).ColumnDataType(0)
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal("GEOMETRY", dataType.Type)
+	suite.Require().NotNil(dataType.SRID)
+	suite.Equal(4326, *dataType.SRID)
+}
+
+func (suite *IntegrationTestSuite) TestPreparedStatementMetadata() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	conn, err := database.Conn(suite.ctx)
+	onError(err)
+	defer conn.Close()
+	var parameterNames []string
+	var parameterTypes, resultColumns []types.SqlQueryColumn
+	err = conn.Raw(func(driverConn any) error {
+		stmt, err := driverConn.(*connection.Connection).PrepareContext(connection.WithNamedParameters(suite.ctx), "SELECT CAST(:id AS DECIMAL(10,0)) AS ID, CAST(? AS VARCHAR(20)) AS NAME")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		statement := stmt.(*connection.Statement)
+		parameterNames = statement.ParameterNames()
+		parameterTypes = statement.ParameterTypes()
+		resultColumns = statement.ResultColumns()
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"id", ""}, parameterNames)
+	suite.Len(parameterTypes, 2)
+	suite.Require().Len(resultColumns, 2)
+	suite.Equal("ID", resultColumns[0].Name)
+	suite.Equal("DECIMAL", resultColumns[0].DataType.Type)
+	suite.Equal("NAME", resultColumns[1].Name)
+	suite.Equal("VARCHAR", resultColumns[1].DataType.Type)
+}
+
+func (suite *IntegrationTestSuite) TestPreparedStatementMetadataWithoutResultSet() {
+	database := suite.openConnection(suite.createDefaultConfig().Autocommit(false))
+	schemaName := "PREPARED_METADATA_TEST"
+	_, err := database.Exec("CREATE SCHEMA " + schemaName)
+	onError(err)
+	defer suite.cleanup(database, schemaName)
+	_, err = database.Exec("CREATE TABLE " + schemaName + ".TEST_TABLE (ID DECIMAL(10,0), NAME VARCHAR(20))")
+	onError(err)
+	conn, err := database.Conn(suite.ctx)
+	onError(err)
+	defer conn.Close()
+	var parameterTypes, resultColumns []types.SqlQueryColumn
+	err = conn.Raw(func(driverConn any) error {
+		stmt, err := driverConn.(*connection.Connection).PrepareContext(suite.ctx, "INSERT INTO "+schemaName+".TEST_TABLE VALUES (?, ?)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		parameterTypes = stmt.(*connection.Statement).ParameterTypes()
+		resultColumns = stmt.(*connection.Statement).ResultColumns()
+		return nil
+	})
+	suite.NoError(err)
+	suite.Require().Len(parameterTypes, 2)
+	suite.Equal("DECIMAL", parameterTypes[0].DataType.Type)
+	suite.Equal("VARCHAR", parameterTypes[1].DataType.Type)
+	suite.Nil(resultColumns)
+}
+
 func (suite *IntegrationTestSuite) TestScanTypeUnsupported() {
 	database := suite.openConnection(suite.createDefaultConfig().Autocommit(false))
 	schemaName := "DATATYPE_TEST"
@@ -519,6 +789,29 @@ func (suite *IntegrationTestSuite) TestQueryWithValuesAndNoContext() {
 	suite.assertSingleValueResult(rows, "15")
 }
 
+func (suite *IntegrationTestSuite) TestQueryWithStatementCache() {
+	database := suite.openConnection(suite.createDefaultConfig().StatementCacheSize(1))
+	schemaName := "TEST_SCHEMA_STATEMENT_CACHE"
+	_, err := database.Exec("CREATE SCHEMA " + schemaName)
+	onError(err)
+	defer suite.cleanup(database, schemaName)
+	_, err = database.Exec("CREATE TABLE " + schemaName + ".TEST_TABLE(x INT)")
+	onError(err)
+	for i := 1; i <= 3; i++ {
+		_, err = database.Exec("INSERT INTO "+schemaName+".TEST_TABLE VALUES (?)", i)
+		suite.NoError(err)
+		rows, err := database.Query("SELECT x FROM "+schemaName+".TEST_TABLE WHERE x = ?", i)
+		suite.NoError(err)
+		suite.assertSingleValueResult(rows, fmt.Sprint(i))
+		suite.NoError(rows.Close())
+	}
+	_, err = database.Exec("INSERT INTO "+schemaName+".TEST_TABLE VALUES (?)", "invalid")
+	suite.Error(err)
+	var count int64
+	suite.NoError(database.QueryRow("SELECT COUNT(*) FROM "+schemaName+".TEST_TABLE WHERE x > ?", 0).Scan(&count))
+	suite.Equal(int64(3), count)
+}
+
 func (suite *IntegrationTestSuite) TestBeginAndCommit() {
 	database := suite.openConnection(suite.createDefaultConfig().Autocommit(false))
 	schemaName := "TEST_SCHEMA_4"
@@ -604,6 +897,80 @@ func (suite *IntegrationTestSuite) TestSimpleImportStatement() {
 	)
 }
 
+func (suite *IntegrationTestSuite) TestImportFromReader() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_IMPORT_READER"
+	tableName := "TEST_TABLE"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.Exec(fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+
+	ctx := connection.WithImportReader(context.Background(), "data.csv", strings.NewReader("1;one\n2;two\n"))
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE 'data.csv' COLUMN SEPARATOR = ';'`, schemaName, tableName))
+	suite.NoError(err, "import should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(2), affectedRows)
+}
+
+func (suite *IntegrationTestSuite) TestImportCompressed() {
+	database := suite.openConnection(suite.createDefaultConfig().ImportCompression(true))
+	schemaName := "TEST_SCHEMA_IMPORT_COMPRESSED"
+	tableName := "TEST_TABLE"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.Exec(fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+
+	ctx := connection.WithImportReader(context.Background(), "data.csv", strings.NewReader("1;one\n2;two\n"))
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE 'data.csv' COLUMN SEPARATOR = ';'`, schemaName, tableName))
+	suite.NoError(err, "import should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(2), affectedRows)
+}
+
+func (suite *IntegrationTestSuite) TestImportFBVFromReader() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_IMPORT_FBV"
+	tableName := "TEST_TABLE"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.Exec(fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+
+	ctx := connection.WithImportReader(context.Background(), "data.fbv", strings.NewReader("1 one  \n2 two  \n"))
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL FBV FILE 'data.fbv' (SIZE=2, SIZE=5)`, schemaName, tableName))
+	suite.NoError(err, "import should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(2), affectedRows)
+}
+
+func (suite *IntegrationTestSuite) TestExportToWriter() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_EXPORT_WRITER"
+	tableName := "TEST_TABLE"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.Exec(fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+	_, _ = database.Exec(fmt.Sprintf("INSERT INTO %s.%s VALUES (1, 'one'), (2, 'two')", schemaName, tableName))
+
+	var buffer strings.Builder
+	ctx := connection.WithExportWriter(context.Background(), "data.csv", &buffer)
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`EXPORT (SELECT * FROM %s.%s ORDER BY a) INTO LOCAL CSV FILE 'data.csv' COLUMN SEPARATOR = ';'`, schemaName, tableName))
+	suite.NoError(err, "export should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(2), affectedRows)
+	suite.Equal("1;one\n2;two\n", buffer.String())
+}
+
+func (suite *IntegrationTestSuite) TestExportToFile() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	path := suite.T().TempDir() + "/export.csv"
+
+	_, err := database.Exec(fmt.Sprintf(`EXPORT (SELECT 1, 'one') INTO LOCAL CSV FILE '%s'`, path))
+	suite.NoError(err, "export should be successful")
+	content, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Equal("1,one\n", string(content))
+}
+
 func (suite *IntegrationTestSuite) TestImportStatementWrongColumns() {
 	database := suite.openConnection(suite.createDefaultConfig())
 	ctx := context.Background()
@@ -628,6 +995,7 @@ func (suite *IntegrationTestSuite) TestImportStatementNotExistentFile() {
 
 	_, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE 'wrong.csv'`, schemaName, tableName))
 	suite.ErrorContains(err, "E-EGOD-11: execution failed with SQL error code '42636' and message 'ETL-5105: Following error occured while reading data from external connection")
+	suite.ErrorContains(err, "E-EGOD-28: file 'wrong.csv' not found")
 }
 
 func (suite *IntegrationTestSuite) TestImportStatementInString() {
@@ -0,0 +1,128 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// fallbackMaxDataMessageSize is used when the server did not report its maximum message size during login.
+const fallbackMaxDataMessageSize = 64 * 1024 * 1024
+
+// BulkInsert executes the prepared statement for all given rows. Each row must contain one value for each parameter.
+// Values are converted like arguments passed via database/sql, see [Statement.CheckNamedValue].
+//
+// The rows are split into chunks that don't exceed the maximum message size reported by the server.
+// The chunks are executed sequentially, the result contains the total number of affected rows.
+// If a chunk fails, BulkInsert returns the number of rows affected by the previous chunks together with the error.
+func (s *Statement) BulkInsert(ctx context.Context, rows [][]any) (driver.Result, error) {
+	if s.connection.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, driver.ErrBadConn
+	}
+	if len(s.columns) == 0 {
+		return nil, errors.ErrInvalidValuesCount
+	}
+	limit, err := s.maxChunkSize()
+	if err != nil {
+		return nil, err
+	}
+	var affectedRows int64
+	chunk := s.newChunk()
+	for _, row := range rows {
+		if len(row) != len(s.columns) {
+			return &RowCount{affectedRows: affectedRows}, errors.ErrInvalidValuesCount
+		}
+		convertedRow, size, err := s.convertRow(row)
+		if err != nil {
+			return &RowCount{affectedRows: affectedRows}, err
+		}
+		if chunk.numRows > 0 && chunk.size+size > limit {
+			rowCount, err := s.executeChunk(ctx, chunk)
+			affectedRows += rowCount
+			if err != nil {
+				return &RowCount{affectedRows: affectedRows}, err
+			}
+			chunk = s.newChunk()
+		}
+		chunk.add(convertedRow, size)
+	}
+	if chunk.numRows > 0 {
+		rowCount, err := s.executeChunk(ctx, chunk)
+		affectedRows += rowCount
+		if err != nil {
+			return &RowCount{affectedRows: affectedRows}, err
+		}
+	}
+	return &RowCount{affectedRows: affectedRows}, nil
+}
+
+// bulkChunk contains the column-wise data of rows sent in a single message.
+type bulkChunk struct {
+	data    [][]interface{}
+	numRows int
+	size    int
+}
+
+func (c *bulkChunk) add(row []interface{}, size int) {
+	for column, value := range row {
+		c.data[column] = append(c.data[column], value)
+	}
+	c.numRows++
+	c.size += size
+}
+
+func (s *Statement) newChunk() *bulkChunk {
+	return &bulkChunk{data: make([][]interface{}, len(s.columns))}
+}
+
+// convertRow converts the values of a row and returns the approximate size of the row in the JSON message.
+func (s *Statement) convertRow(row []any) ([]interface{}, int, error) {
+	convertedRow := make([]interface{}, len(row))
+	for column, value := range row {
+		checkedValue, err := checkValue(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		convertedValue, err := convertArg(checkedValue, s.columns[column].DataType, s.connection.sessionLocation())
+		if err != nil {
+			return nil, 0, err
+		}
+		convertedRow[column] = convertedValue
+	}
+	encoded, err := json.Marshal(convertedRow)
+	if err != nil {
+		return nil, 0, errors.NewMarshallingError(row, err)
+	}
+	return convertedRow, len(encoded), nil
+}
+
+// maxChunkSize returns the maximum size of the data in a single message.
+func (s *Statement) maxChunkSize() (int, error) {
+	maxMessageSize := fallbackMaxDataMessageSize
+	if s.connection.authResponse != nil && s.connection.authResponse.MaxDataMessageSize > 0 {
+		maxMessageSize = s.connection.authResponse.MaxDataMessageSize
+	}
+	// The command without data contains the column metadata
+	emptyCommand, err := json.Marshal(s.createExecuteCommand(s.newChunk().data, 0))
+	if err != nil {
+		return 0, errors.NewMarshallingError(s.columns, err)
+	}
+	return maxMessageSize - len(emptyCommand), nil
+}
+
+func (s *Statement) executeChunk(ctx context.Context, chunk *bulkChunk) (int64, error) {
+	logger.TraceLogger.Printf("Inserting chunk of %d rows with approximately %d bytes", chunk.numRows, chunk.size)
+	response, err := s.sendData(ctx, chunk.data, chunk.numRows)
+	if err != nil {
+		return 0, err
+	}
+	result, err := ToResult(response)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,134 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type BulkInsertTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestBulkInsertSuite(t *testing.T) {
+	suite.Run(t, new(BulkInsertTestSuite))
+}
+
+func (suite *BulkInsertTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+var bulkInsertColumns = []types.SqlQueryColumn{
+	{Name: "ID", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}},
+	{Name: "NAME", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}},
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertInSingleChunk() {
+	suite.simulateExecute([][]interface{}{{1, 2, 3}, {"a", "b", "c"}}, 3)
+	result, err := suite.createStatement(1024).BulkInsert(context.Background(), [][]any{{1, "a"}, {2, "b"}, {3, "c"}})
+	suite.NoError(err)
+	suite.assertRowsAffected(3, result)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertSplitsChunks() {
+	suite.simulateExecute([][]interface{}{{1, 2}, {"a", "b"}}, 2)
+	suite.simulateExecute([][]interface{}{{3}, {"c"}}, 1)
+	statement := suite.createStatement(0)
+	limit, err := statement.maxChunkSize()
+	suite.Require().NoError(err)
+	// Each row has 7 bytes, e.g. [1,"a"]
+	statement.connection.authResponse.MaxDataMessageSize = fallbackMaxDataMessageSize - limit + 14
+	result, err := statement.BulkInsert(context.Background(), [][]any{{1, "a"}, {2, "b"}, {3, "c"}})
+	suite.NoError(err)
+	suite.assertRowsAffected(3, result)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertSendsRowsExceedingLimitSeparately() {
+	suite.simulateExecute([][]interface{}{{1}, {"a"}}, 1)
+	suite.simulateExecute([][]interface{}{{2}, {"b"}}, 1)
+	result, err := suite.createStatement(1).BulkInsert(context.Background(), [][]any{{1, "a"}, {2, "b"}})
+	suite.NoError(err)
+	suite.assertRowsAffected(2, result)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertWithoutRows() {
+	result, err := suite.createStatement(1024).BulkInsert(context.Background(), nil)
+	suite.NoError(err)
+	suite.assertRowsAffected(0, result)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertFailsForInvalidRowLength() {
+	result, err := suite.createStatement(1024).BulkInsert(context.Background(), [][]any{{1, "a"}, {2}})
+	suite.EqualError(err, "E-EGOD-5: invalid value count for prepared status")
+	suite.assertRowsAffected(0, result)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertReturnsRowsAffectedBeforeFailure() {
+	suite.simulateExecute([][]interface{}{{1}, {"a"}}, 1)
+	suite.websocketMock.SimulateErrorResponse(suite.createExecuteCommand([][]interface{}{{2}, {"b"}}, 1), mockException)
+	result, err := suite.createStatement(1).BulkInsert(context.Background(), [][]any{{1, "a"}, {2, "b"}})
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.assertRowsAffected(1, result)
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertFailsWithClosedConnection() {
+	statement := suite.createStatement(1024)
+	statement.connection.IsClosed = true
+	result, err := statement.BulkInsert(context.Background(), [][]any{{1, "a"}})
+	suite.Same(driver.ErrBadConn, err)
+	suite.Nil(result)
+}
+
+func (suite *BulkInsertTestSuite) TestBulkInsertUsesFallbackMessageSize() {
+	statement := suite.createStatement(0)
+	statement.connection.authResponse = nil
+	limit, err := statement.maxChunkSize()
+	suite.NoError(err)
+	suite.Less(limit, fallbackMaxDataMessageSize)
+	suite.Greater(limit, fallbackMaxDataMessageSize-1024)
+}
+
+func (suite *BulkInsertTestSuite) simulateExecute(data [][]interface{}, numRows int) {
+	suite.websocketMock.SimulateSQLQueriesResponse(suite.createExecuteCommand(data, numRows),
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: numRows})
+}
+
+func (suite *BulkInsertTestSuite) createExecuteCommand(data [][]interface{}, numRows int) types.ExecutePreparedStatementCommand {
+	return types.ExecutePreparedStatementCommand{
+		Command:         types.Command{Command: "executePreparedStatement"},
+		StatementHandle: 1,
+		Columns:         bulkInsertColumns,
+		NumColumns:      2,
+		NumRows:         numRows,
+		Data:            data,
+	}
+}
+
+func (suite *BulkInsertTestSuite) assertRowsAffected(expected int64, result driver.Result) {
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(expected, rowsAffected)
+}
+
+func (suite *BulkInsertTestSuite) createStatement(maxDataMessageSize int) *Statement {
+	conn := &Connection{
+		Config:       &config.Config{Host: "invalid", Port: 12345},
+		Ctx:          context.Background(),
+		IsClosed:     false,
+		websocket:    suite.websocketMock,
+		authResponse: &types.AuthResponse{MaxDataMessageSize: maxDataMessageSize},
+	}
+	return NewStatement(conn, &types.CreatePreparedStatementResponse{StatementHandle: 1,
+		ParameterData: types.ParameterData{NumColumns: 2, Columns: bulkInsertColumns}})
+}
@@ -4,15 +4,21 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"math"
 	"math/big"
+	"net"
 	"os/user"
 	"runtime"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/internal/utils"
@@ -25,14 +31,24 @@ import (
 )
 
 type Connection struct {
-	Config    *config.Config
-	websocket wsconn.WebsocketConnection
-	Ctx       context.Context
-	IsClosed  bool
+	Config          *config.Config
+	websocket       wsconn.WebsocketConnection
+	host            string // host of the websocket connection, see Connect
+	dispatcher      *dispatcher
+	dispatcherMutex sync.Mutex
+	session         sessionState
+	sessionMutex    sync.Mutex
+	authResponse    *types.AuthResponse // server properties reported at login
+	location        *time.Location      // session time zone, see sessionLocation
+	Ctx             context.Context
+	IsClosed        bool
+
+	statementCache      *statementCache // prepared statements reused by Query and Exec, see getStatementCache
+	statementCacheMutex sync.Mutex
 }
 
 func (c *Connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	values, err := utils.NamedValuesToValues(args)
+	query, values, err := bindArguments(query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +56,7 @@ func (c *Connection) QueryContext(ctx context.Context, query string, args []driv
 }
 
 func (c *Connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	values, err := utils.NamedValuesToValues(args)
+	query, values, err := bindArguments(query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +77,17 @@ func (c *Connection) PrepareContext(ctx context.Context, query string) (driver.S
 		return nil, driver.ErrBadConn
 	}
 
+	var parameters utils.NamedParameters
+	if namedParametersEnabled(ctx) {
+		query, parameters = utils.ReplaceNamedParameters(query)
+	}
 	response, err := c.createPreparedStatement(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	return c.createStatement(response), nil
+	statement := c.createStatement(response)
+	statement.namedParameters = parameters
+	return statement, nil
 }
 
 func (c *Connection) createPreparedStatement(ctx context.Context, query string) (*types.CreatePreparedStatementResponse, error) {
@@ -106,6 +128,48 @@ func (c *Connection) Begin() (driver.Tx, error) {
 	return NewTransaction(c), nil
 }
 
+// BeginTx implements the [driver.ConnBeginTx] interface.
+// If autocommit is enabled, it is disabled for the duration of the transaction and enabled again
+// when the transaction is committed or rolled back.
+// Exasol only supports isolation level [sql.LevelSerializable] and can't enforce read-only transactions.
+func (c *Connection) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, driver.ErrBadConn
+	}
+	isolationLevel := sql.IsolationLevel(opts.Isolation)
+	if isolationLevel != sql.LevelDefault && isolationLevel != sql.LevelSerializable {
+		return nil, errors.NewErrUnsupportedIsolationLevel(isolationLevel.String())
+	}
+	if opts.ReadOnly {
+		return nil, errors.ErrReadOnlyTransactionNotSupported
+	}
+	if !c.getSessionState().autocommit {
+		return NewTransaction(c), nil
+	}
+	err := c.setAttributes(ctx, types.Attributes{Autocommit: utils.BoolToPtr(false)})
+	if err != nil {
+		return nil, err
+	}
+	// Restore the autocommit mode the session had before the transaction
+	return &Transaction{connection: c, restoreAttributes: &types.Attributes{Autocommit: utils.BoolToPtr(true)}}, nil
+}
+
+// Ping implements the [driver.Pinger] interface. It sends a getAttributes command to the database
+// to verify that the session is still alive.
+func (c *Connection) Ping(ctx context.Context) error {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return driver.ErrBadConn
+	}
+	err := c.Send(ctx, &types.Command{Command: "getAttributes"}, nil)
+	if err != nil && stderrors.Is(err, driver.ErrBadConn) {
+		logger.ErrorLogger.Printf("Ping failed: %v", err)
+		return driver.ErrBadConn
+	}
+	return err
+}
+
 func (c *Connection) query(ctx context.Context, query string, args []driver.Value) (driver.Rows, error) {
 	if c.IsClosed {
 		logger.ErrorLogger.Print(errors.ErrClosed)
@@ -117,16 +181,102 @@ func (c *Connection) query(ctx context.Context, query string, args []driver.Valu
 		return c.executeSimpleWithRows(ctx, query)
 	}
 
-	response, err := c.createPreparedStatement(ctx, query)
+	result, err := c.executeCachedPreparedStatement(ctx, query, args)
 	if err != nil {
 		return nil, err
 	}
+	return ToRow(result, c)
+}
 
-	result, err := c.executePreparedStatement(ctx, response, args)
+// QueryBatch executes the given statements in a single round trip using Exasol's executeBatch command.
+// The returned rows contain one result for each statement, use [QueryResults.NextResultSet] to iterate them.
+func (c *Connection) QueryBatch(ctx context.Context, queries []string) (driver.Rows, error) {
+	result, err := c.executeBatch(ctx, queries)
 	if err != nil {
 		return nil, err
 	}
-	return ToRow(result, c)
+	return toBatchRows(result, c)
+}
+
+// ExecBatch executes the given statements in a single round trip using Exasol's executeBatch command
+// and returns one result for each statement.
+// When a statement fails, ExecBatch returns the results of the statements executed before and an [errors.BatchError]
+// containing the index of the failing statement.
+func (c *Connection) ExecBatch(ctx context.Context, statements []string) ([]driver.Result, error) {
+	response, err := c.executeBatch(ctx, statements)
+	if response == nil {
+		return nil, err
+	}
+	results, resultErr := c.toBatchResults(ctx, response)
+	if err != nil {
+		if !isSqlError(err) {
+			return results, err
+		}
+		// Without partial results the database did not report which statement failed
+		index := -1
+		if response.Results != nil {
+			index = len(response.Results)
+		}
+		return results, errors.NewBatchError(index, err)
+	}
+	return results, resultErr
+}
+
+// toBatchResults converts each result of a batch to a row count and closes result sets returned by queries in the batch.
+func (c *Connection) toBatchResults(ctx context.Context, response *types.SqlQueriesResponse) ([]driver.Result, error) {
+	results := make([]driver.Result, 0, len(response.Results))
+	var handles []int
+	for _, result := range response.Results {
+		rowCount := &types.SqlQueryResponseRowCount{}
+		if err := json.Unmarshal(result, rowCount); err != nil {
+			return results, err
+		}
+		if rowCount.ResultType == types.ResultTypeResultSet {
+			resultSet := &types.SqlQueryResponseResultSet{}
+			if err := json.Unmarshal(result, resultSet); err != nil {
+				return results, err
+			}
+			if resultSet.ResultSet.ResultSetHandle != 0 {
+				handles = append(handles, resultSet.ResultSet.ResultSetHandle)
+			}
+		}
+		results = append(results, &RowCount{affectedRows: int64(rowCount.RowCount)})
+	}
+	if len(handles) > 0 {
+		return results, c.Send(ctx, &types.CloseResultSetCommand{
+			Command:          types.Command{Command: "closeResultSet"},
+			ResultSetHandles: handles,
+		}, nil)
+	}
+	return results, nil
+}
+
+// executeBatch executes the given statements. In case of an SQL error, it returns the partial results reported by the database.
+func (c *Connection) executeBatch(ctx context.Context, queries []string) (*types.SqlQueriesResponse, error) {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, driver.ErrBadConn
+	}
+	if len(queries) == 0 {
+		return nil, errors.ErrEmptyBatch
+	}
+	command := &types.BatchCommand{
+		Command:  types.Command{Command: "executeBatch"},
+		SQLTexts: queries,
+		Attributes: types.Attributes{
+			ResultSetMaxRows: c.Config.ResultSetMaxRows,
+		},
+	}
+	result := &types.SqlQueriesResponse{}
+	err := c.Send(ctx, command, result)
+	if err != nil {
+		return result, err
+	}
+	if result.NumResults == 0 {
+		logger.ErrorLogger.Printf("Got empty result of type %t: %v", result, result)
+		return nil, errors.ErrMalformedData
+	}
+	return result, nil
 }
 
 func (c *Connection) executeSimpleWithRows(ctx context.Context, query string) (driver.Rows, error) {
@@ -172,7 +322,50 @@ func (c *Connection) executePreparedStatement(ctx context.Context, s *types.Crea
 		logger.ErrorLogger.Printf("Got empty result of type %t: %v", result, result)
 		return nil, errors.ErrMalformedData
 	}
-	return result, c.closePreparedStatement(ctx, s)
+	return result, nil
+}
+
+// executeCachedPreparedStatement executes the query as prepared statement with the given arguments.
+// The prepared statement is taken from and returned to the statement cache if it is enabled.
+// A cached statement may have become invalid since it was prepared, e.g. because DDL changed a table it uses.
+// So if the database reports an invalid statement handle for a cached statement, the query is prepared and executed
+// again once. This is not done in an open transaction because the error may have rolled back the transaction.
+func (c *Connection) executeCachedPreparedStatement(ctx context.Context, query string, args []driver.Value) (*types.SqlQueriesResponse, error) {
+	key := c.statementCacheKey(query)
+	statement, cached, err := c.acquirePreparedStatement(ctx, key, query)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.executePreparedStatement(ctx, statement, args)
+	if err != nil && cached && isInvalidStatementError(err) && c.getSessionState().autocommit {
+		logger.TraceLogger.Printf("Execution of cached prepared statement %d failed, preparing it again: %v", statement.StatementHandle, err)
+		c.discardPreparedStatement(ctx, statement)
+		if statement, err = c.createPreparedStatement(ctx, query); err != nil {
+			return nil, err
+		}
+		result, err = c.executePreparedStatement(ctx, statement, args)
+	}
+	if err != nil {
+		c.discardPreparedStatement(ctx, statement)
+		return nil, err
+	}
+	return result, c.releasePreparedStatement(ctx, key, statement)
+}
+
+// isSqlError returns true if the database rejected the statement with an SQL error code.
+func isSqlError(err error) bool {
+	var driverErr errors.DriverErr
+	return stderrors.As(err, &driverErr) && driverErr.SQLCode() != ""
+}
+
+// invalidStatementSQLCode is the SQL state "invalid SQL statement name" that the database reports
+// for unknown or outdated prepared statement handles.
+const invalidStatementSQLCode = "26000"
+
+// isInvalidStatementError returns true if the database rejected a prepared statement because its handle is invalid.
+func isInvalidStatementError(err error) bool {
+	var driverErr errors.DriverErr
+	return stderrors.As(err, &driverErr) && driverErr.SQLCode() == invalidStatementSQLCode
 }
 
 func (c *Connection) closePreparedStatement(ctx context.Context, s *types.CreatePreparedStatementResponse) error {
@@ -190,22 +383,41 @@ func (c *Connection) exec(ctx context.Context, query string, args []driver.Value
 	result := make(chan driver.Result, 1)
 	errs, errctx := errgroup.WithContext(ctx)
 
+	var importStatement *ImportStatement
+	var upload chan error
 	if utils.IsImportQuery(query) {
-		importStatement, err := NewImportStatement(query, c.Config.Host, c.Config.Port)
+		var err error
+		importStatement, err = c.newImportStatement(ctx, query)
 		if err != nil {
 			return nil, err
 		}
 
 		query = importStatement.GetUpdatedQuery()
+		upload = make(chan error, 1)
 		// The upload is not part of the errgroup to avoid that an error in the upload prevents the statement from
 		// finishing and returning the error from the database.
+		// Use the parent context because errctx is cancelled when the statement finished
 		go func() {
 			// Close right after the upload to ensure that the IMPORT statement can proceed in case of an error
 			defer importStatement.Close()
-			uploadErr := importStatement.UploadFiles(errctx)
-			if uploadErr != nil {
-				logger.ErrorLogger.Printf("Error uploading files: %v", uploadErr)
-			}
+			upload <- importStatement.UploadFiles(ctx)
+		}()
+	}
+	var exportStatement *ExportStatement
+	var download chan error
+	if utils.IsExportQuery(query) {
+		var err error
+		exportStatement, err = NewExportStatement(query, c.exportHost(), c.Config.Port, c.Config.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		defer exportStatement.Close()
+
+		query = exportStatement.GetUpdatedQuery()
+		download = make(chan error, 1)
+		// Use the parent context because errctx is cancelled when the statement finished
+		go func() {
+			download <- exportStatement.DownloadFile(ctx)
 		}()
 	}
 	// No values provided, simple execute is enough
@@ -217,6 +429,12 @@ func (c *Connection) exec(ctx context.Context, query string, args []driver.Value
 	err := errs.Wait()
 	close(result)
 
+	if importStatement != nil {
+		err = waitForUpload(ctx, importStatement, upload, err)
+	}
+	if exportStatement != nil {
+		err = waitForDownload(exportStatement, download, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -224,6 +442,61 @@ func (c *Connection) exec(ctx context.Context, query string, args []driver.Value
 	return <-result, nil
 }
 
+// uploadErrorTimeout is the time to wait for the upload after the statement failed.
+const uploadErrorTimeout = time.Second
+
+// waitForUpload waits until the upload finished and returns the errors of the statement and the upload.
+// The upload error often explains the error of the statement, e.g. if a file does not exist.
+// After the statement failed it waits at most [uploadErrorTimeout], because closing the proxies does not
+// unblock a reader registered with [WithImportReader].
+func waitForUpload(ctx context.Context, importStatement *ImportStatement, upload <-chan error, statementErr error) error {
+	var timeout <-chan time.Time
+	if statementErr != nil {
+		// Unblock the upload in case the database stopped reading the data
+		importStatement.Close()
+		timeout = time.After(uploadErrorTimeout)
+	}
+	var uploadErr error
+	select {
+	case uploadErr = <-upload:
+	case <-timeout:
+		logger.ErrorLogger.Print("Upload did not finish after the statement failed")
+		return statementErr
+	case <-ctx.Done():
+		if statementErr != nil {
+			return statementErr
+		}
+		return ctx.Err()
+	}
+	if uploadErr == nil {
+		return statementErr
+	}
+	logger.ErrorLogger.Printf("Error uploading files: %v", uploadErr)
+	if statementErr != nil && (stderrors.Is(uploadErr, context.Canceled) || stderrors.Is(uploadErr, net.ErrClosed)) {
+		// The upload was aborted because the statement failed
+		return statementErr
+	}
+	return stderrors.Join(statementErr, uploadErr)
+}
+
+// waitForDownload waits until the exported data was received. The database finishes the EXPORT statement
+// only after the download is complete, so an error of the statement takes precedence.
+func waitForDownload(exportStatement *ExportStatement, download <-chan error, statementErr error) error {
+	if statementErr != nil {
+		// Unblock the download in case the database did not connect to the proxy
+		exportStatement.Close()
+		if downloadErr := <-download; downloadErr != nil {
+			logger.ErrorLogger.Printf("Error downloading file: %v", downloadErr)
+		}
+		exportStatement.RemoveDownloadedFile()
+		return statementErr
+	}
+	if downloadErr := <-download; downloadErr != nil {
+		return downloadErr
+	}
+	return exportStatement.MoveDownloadedFile()
+}
+
 func (c *Connection) executeSimpleWrapper(ctx context.Context, query string, result chan driver.Result) func() error {
 	return func() error {
 		r, err := c.executeSimpleWithResult(ctx, query)
@@ -237,15 +510,7 @@ func (c *Connection) executeSimpleWrapper(ctx context.Context, query string, res
 
 func (c *Connection) executePreparedStatementWrapper(ctx context.Context, query string, args []driver.Value, result chan driver.Result) func() error {
 	return func() error {
-		prepResponse := &types.CreatePreparedStatementResponse{}
-		err := c.Send(ctx, &types.CreatePreparedStatementCommand{
-			Command: types.Command{Command: "createPreparedStatement"},
-			SQLText: query,
-		}, prepResponse)
-		if err != nil {
-			return err
-		}
-		resp, err := c.executePreparedStatement(ctx, prepResponse, args)
+		resp, err := c.executeCachedPreparedStatement(ctx, query, args)
 		if err != nil {
 			return err
 		}
@@ -287,10 +552,16 @@ func (c *Connection) SimpleExec(ctx context.Context, query string) (*types.SqlQu
 }
 
 func (c *Connection) close(ctx context.Context) error {
+	if err := c.closeCachedStatements(ctx); err != nil {
+		logger.ErrorLogger.Printf("Failed to close cached prepared statements: %v", err)
+	}
 	c.IsClosed = true
 	err := c.Send(ctx, &types.Command{Command: "disconnect"}, nil)
 	closeError := c.websocket.Close()
 	c.websocket = nil
+	c.dispatcherMutex.Lock()
+	c.dispatcher = nil
+	c.dispatcherMutex.Unlock()
 	if err != nil {
 		return err
 	}
@@ -303,6 +574,7 @@ func (c *Connection) close(ctx context.Context) error {
 func (c *Connection) Login(ctx context.Context) error {
 	hasCompression := c.Config.Compression
 	c.Config.Compression = false
+	c.initSessionState()
 
 	authRequest, err := c.preLogin(ctx, hasCompression)
 	if err != nil {
@@ -322,6 +594,7 @@ func (c *Connection) Login(ctx context.Context) error {
 		return fmt.Errorf("failed to login: %w", err)
 	}
 	c.IsClosed = false
+	c.authResponse = authResponse
 
 	return nil
 }
@@ -413,3 +686,21 @@ func (c *Connection) prepareLoginViaToken(ctx context.Context) error {
 	}
 	return nil
 }
+
+// sessionLocation returns the location of the session time zone reported at login
+// used for TIMESTAMP WITH LOCAL TIME ZONE values. It falls back to UTC if the time zone is unknown.
+func (c *Connection) sessionLocation() *time.Location {
+	if c.location != nil {
+		return c.location
+	}
+	c.location = time.UTC
+	if c.authResponse != nil && c.authResponse.TimeZone != "" {
+		location, err := loadSessionLocation(c.authResponse.TimeZone)
+		if err != nil {
+			logger.ErrorLogger.Printf("Failed to load session time zone %q, using UTC: %v", c.authResponse.TimeZone, err)
+		} else {
+			c.location = location
+		}
+	}
+	return c.location
+}
@@ -2,11 +2,15 @@ package connection
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/types"
@@ -42,12 +46,37 @@ func (suite *ConnectionTestSuite) TestConnectFails() {
 	suite.ErrorContains(err, `failed to connect to URL "ws://invalid:12345": dial tcp`)
 }
 
-func (suite *ConnectionTestSuite) TestQueryContextNamedParametersNotSupported() {
+func (suite *ConnectionTestSuite) TestQueryContextUnknownNamedParameter() {
 	rows, err := suite.createOpenConnection().QueryContext(context.Background(), "query", []driver.NamedValue{{Name: "arg", Ordinal: 1, Value: "value"}})
-	suite.EqualError(err, "E-EGOD-7: named parameters not supported")
+	suite.EqualError(err, "E-EGOD-33: named parameter 'arg' is not used in the query")
 	suite.Nil(rows)
 }
 
+func (suite *ConnectionTestSuite) TestQueryContextNamedParameters() {
+	columns := []types.SqlQueryColumn{{Name: "a", DataType: types.SqlQueryColumnType{Type: "type"}}, {Name: "b", DataType: types.SqlQueryColumnType{Type: "type"}}}
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "select * from t where a = ? and b = ?",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{ParameterData: types.ParameterData{Columns: columns}})
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.ExecutePreparedStatementCommand{Command: types.Command{Command: "executePreparedStatement"},
+			StatementHandle: 0, NumColumns: 2, NumRows: 1,
+			Columns: columns,
+			Data:    [][]interface{}{{"value a"}, {"value b"}},
+		},
+		types.SqlQueryResponseResultSet{ResultType: "resultType", ResultSet: types.SqlQueryResponseResultSetData{}})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 0, Attributes: types.Attributes{}}, nil)
+
+	rows, err := suite.createOpenConnection().QueryContext(context.Background(), "select * from t where a = :a and b = @b",
+		[]driver.NamedValue{{Name: "b", Ordinal: 1, Value: "value b"}, {Name: "a", Ordinal: 2, Value: "value a"}})
+	suite.NoError(err)
+	suite.Equal([]string{}, rows.Columns())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
 func (suite *ConnectionTestSuite) TestQueryContext() {
 	suite.websocketMock.SimulateOKResponse(
 		types.SqlCommand{
@@ -94,12 +123,89 @@ func (suite *ConnectionTestSuite) TestQuery() {
 	suite.Equal([]string{}, rows.Columns())
 }
 
-func (suite *ConnectionTestSuite) TestExecContextNamedParametersNotSupported() {
+func (suite *ConnectionTestSuite) TestExecContextUnknownNamedParameter() {
 	rows, err := suite.createOpenConnection().ExecContext(context.Background(), "query", []driver.NamedValue{{Name: "arg", Ordinal: 1, Value: "value"}})
-	suite.EqualError(err, "E-EGOD-7: named parameters not supported")
+	suite.EqualError(err, "E-EGOD-33: named parameter 'arg' is not used in the query")
 	suite.Nil(rows)
 }
 
+func (suite *ConnectionTestSuite) TestExecContextMissingNamedParameter() {
+	rows, err := suite.createOpenConnection().ExecContext(context.Background(), "insert into t values (:a, :b)", []driver.NamedValue{{Name: "a", Ordinal: 1, Value: "value"}})
+	suite.EqualError(err, "E-EGOD-34: no value given for parameter 'b'")
+	suite.Nil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestExecContextWithoutArgsKeepsScriptBody() {
+	script := "CREATE JAVA SCALAR SCRIPT s(n INT) RETURNS INT AS\n" +
+		"class S { @Override public String toString() { return \"\"; } }\n" +
+		"/\n"
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: script, Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: types.ResultTypeRowCount})
+	_, err := suite.createOpenConnection().ExecContext(context.Background(), script, nil)
+	suite.NoError(err)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestExecContextPositionalArgsKeepsNamedTokens() {
+	query := "CREATE PYTHON3 SCALAR SCRIPT s(t VARCHAR(10), n INT) RETURNS VARCHAR(10) AS\n" +
+		"def run(ctx):\n    return ctx.t[:ctx.n] + '@x'\n/\n"
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: query, Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: types.ResultTypeRowCount})
+	_, err := suite.createOpenConnection().ExecContext(context.Background(), query, []driver.NamedValue{})
+	suite.NoError(err)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestQueryContextPositionalArgsKeepsNamedTokens() {
+	columns := []types.SqlQueryColumn{{Name: "a", DataType: types.SqlQueryColumnType{Type: "type"}}}
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "select s(t[:n]) from t where a = ?",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{ParameterData: types.ParameterData{Columns: columns}})
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.ExecutePreparedStatementCommand{Command: types.Command{Command: "executePreparedStatement"},
+			StatementHandle: 0, NumColumns: 1, NumRows: 1,
+			Columns: columns,
+			Data:    [][]interface{}{{"value"}},
+		},
+		types.SqlQueryResponseResultSet{ResultType: "resultType", ResultSet: types.SqlQueryResponseResultSetData{}})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 0, Attributes: types.Attributes{}}, nil)
+
+	_, err := suite.createOpenConnection().QueryContext(context.Background(), "select s(t[:n]) from t where a = ?", []driver.NamedValue{{Ordinal: 1, Value: "value"}})
+	suite.NoError(err)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestExecContextNamedParameters() {
+	columns := []types.SqlQueryColumn{{Name: "col", DataType: types.SqlQueryColumnType{Type: "type"}}}
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "insert into t values (?)",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{ParameterData: types.ParameterData{Columns: columns}})
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.ExecutePreparedStatementCommand{Command: types.Command{Command: "executePreparedStatement"},
+			StatementHandle: 0, NumColumns: 1, NumRows: 1,
+			Columns: columns,
+			Data:    [][]interface{}{{"value"}},
+		},
+		types.SqlQueryResponseRowCount{ResultType: "resultType", RowCount: 1})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 0, Attributes: types.Attributes{}}, nil)
+
+	result, err := suite.createOpenConnection().ExecContext(context.Background(), "insert into t values (:col)", []driver.NamedValue{{Name: "col", Ordinal: 1, Value: "value"}})
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(1), rowsAffected)
+}
+
 func (suite *ConnectionTestSuite) TestExecContext() {
 	suite.websocketMock.SimulateOKResponse(
 		types.SqlCommand{
@@ -150,6 +256,177 @@ func (suite *ConnectionTestSuite) TestExec() {
 	suite.Equal(int64(42), rowsAffected)
 }
 
+func (suite *ConnectionTestSuite) TestPrepareContextReturnsMetadata() {
+	parameters := []types.SqlQueryColumn{{Name: "a", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}, {Name: "b", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}, {Name: "c", DataType: types.SqlQueryColumnType{Type: "DATE"}}}
+	resultColumns := []types.SqlQueryColumn{{Name: "X", DataType: types.SqlQueryColumnType{Type: "BOOLEAN"}}}
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "select x from t where a = ? and b = ? and c = ?",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			StatementHandle: 17,
+			ParameterData:   types.ParameterData{NumColumns: 3, Columns: parameters},
+			SqlQueriesResponse: types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{wsconn.JsonMarshall(
+				types.SqlQueryResponseResultSet{ResultType: types.ResultTypeResultSet, ResultSet: types.SqlQueryResponseResultSetData{NumColumns: 1, Columns: resultColumns}})}},
+		})
+
+	stmt, err := suite.createOpenConnection().PrepareContext(WithNamedParameters(context.Background()), "select x from t where a = :a and b = ? and c = @c")
+	suite.NoError(err)
+	statement := stmt.(*Statement)
+	suite.Equal(3, statement.NumInput())
+	suite.Equal(parameters, statement.ParameterTypes())
+	suite.Equal([]string{"a", "", "c"}, statement.ParameterNames())
+	suite.Equal(resultColumns, statement.ResultColumns())
+}
+
+func (suite *ConnectionTestSuite) TestPrepareContextWithoutResultSet() {
+	parameters := []types.SqlQueryColumn{{Name: "a", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}}
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "insert into t values (?)",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			ParameterData: types.ParameterData{NumColumns: 1, Columns: parameters},
+			SqlQueriesResponse: types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{wsconn.JsonMarshall(
+				types.SqlQueryResponseRowCount{ResultType: types.ResultTypeRowCount})}},
+		})
+
+	stmt, err := suite.createOpenConnection().PrepareContext(context.Background(), "insert into t values (?)")
+	suite.NoError(err)
+	statement := stmt.(*Statement)
+	suite.Equal(parameters, statement.ParameterTypes())
+	suite.Equal([]string{""}, statement.ParameterNames())
+	suite.Nil(statement.ResultColumns())
+}
+
+func (suite *ConnectionTestSuite) TestParameterTypesReturnsCopy() {
+	statement := &Statement{columns: []types.SqlQueryColumn{{Name: "a"}}, resultColumns: []types.SqlQueryColumn{{Name: "b"}}}
+	statement.ParameterTypes()[0].Name = "modified"
+	statement.ResultColumns()[0].Name = "modified"
+	suite.Equal("a", statement.columns[0].Name)
+	suite.Equal("b", statement.resultColumns[0].Name)
+}
+
+func (suite *ConnectionTestSuite) TestQueryWithRowCountResultReturnsNoRows() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "delete from t", Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: types.ResultTypeRowCount, RowCount: 3})
+
+	rows, err := suite.createOpenConnection().QueryContext(context.Background(), "delete from t", nil)
+	suite.NoError(err)
+	suite.Empty(rows.Columns())
+	suite.Same(io.EOF, rows.Next(nil))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestQueryBatch() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"insert", "select"}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 3},
+		types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{
+			NumRows: 1, NumRowsInMessage: 1, Columns: []types.SqlQueryColumn{{Name: "col"}}, Data: [][]interface{}{{"value"}}}})
+
+	rows, err := suite.createOpenConnection().QueryBatch(context.Background(), []string{"insert", "select"})
+	suite.NoError(err)
+	suite.Equal([]string{"ROWS_AFFECTED"}, rows.Columns())
+	dest := make([]driver.Value, 1)
+	suite.NoError(rows.Next(dest))
+	suite.Equal([]driver.Value{int64(3)}, dest)
+
+	resultSets := rows.(driver.RowsNextResultSet)
+	suite.True(resultSets.HasNextResultSet())
+	suite.NoError(resultSets.NextResultSet())
+	suite.Equal([]string{"col"}, rows.Columns())
+	suite.NoError(rows.Next(dest))
+	suite.Equal([]driver.Value{"value"}, dest)
+	suite.False(resultSets.HasNextResultSet())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestQueryBatchFails() {
+	suite.websocketMock.SimulateErrorResponse(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"invalid"}}, mockException)
+	rows, err := suite.createOpenConnection().QueryBatch(context.Background(), []string{"invalid"})
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestQueryBatchWithoutStatements() {
+	rows, err := suite.createOpenConnection().QueryBatch(context.Background(), nil)
+	suite.EqualError(err, "E-EGOD-36: batch does not contain any statements")
+	suite.Nil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestQueryBatchFailsConnectionClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	rows, err := conn.QueryBatch(context.Background(), []string{"select"})
+	suite.Same(driver.ErrBadConn, err)
+	suite.Nil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestExecBatch() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"create", "insert", "select"}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 0},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 3},
+		types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{ResultSetHandle: 17}})
+	suite.websocketMock.SimulateOKResponse(types.CloseResultSetCommand{Command: types.Command{Command: "closeResultSet"}, ResultSetHandles: []int{17}}, nil)
+
+	results, err := suite.createOpenConnection().ExecBatch(context.Background(), []string{"create", "insert", "select"})
+	suite.NoError(err)
+	suite.Equal([]driver.Result{&RowCount{affectedRows: 0}, &RowCount{affectedRows: 3}, &RowCount{affectedRows: 0}}, results)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestExecBatchFailsWithPartialResults() {
+	suite.websocketMock.SimulateResponse(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"create", "insert", "invalid"}},
+		types.BaseResponse{Status: "error", Exception: &mockException, ResponseData: wsconn.JsonMarshall(types.SqlQueriesResponse{NumResults: 2, Results: []json.RawMessage{
+			wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 0}),
+			wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 3}),
+		}})})
+
+	results, err := suite.createOpenConnection().ExecBatch(context.Background(), []string{"create", "insert", "invalid"})
+	suite.Equal([]driver.Result{&RowCount{affectedRows: 0}, &RowCount{affectedRows: 3}}, results)
+	var batchErr errors.BatchError
+	suite.Require().ErrorAs(err, &batchErr)
+	suite.Equal(2, batchErr.Index)
+	suite.EqualError(err, "E-EGOD-37: execution of batch failed at statement '2': '"+mockExceptionError(mockException)+"'")
+}
+
+func (suite *ConnectionTestSuite) TestExecBatchFailsWithoutPartialResults() {
+	suite.websocketMock.SimulateErrorResponse(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"invalid"}}, mockException)
+
+	results, err := suite.createOpenConnection().ExecBatch(context.Background(), []string{"invalid"})
+	suite.Empty(results)
+	var batchErr errors.BatchError
+	suite.Require().ErrorAs(err, &batchErr)
+	suite.Equal(-1, batchErr.Index)
+}
+
+func (suite *ConnectionTestSuite) TestExecBatchFailsWithOtherError() {
+	suite.websocketMock.SimulateWriteFails(
+		types.BatchCommand{Command: types.Command{Command: "executeBatch"}, SQLTexts: []string{"insert"}}, fmt.Errorf("mock error"))
+
+	results, err := suite.createOpenConnection().ExecBatch(context.Background(), []string{"insert"})
+	suite.Empty(results)
+	suite.EqualError(err, "W-EGOD-16: could not send request: 'mock error'")
+}
+
+func (suite *ConnectionTestSuite) TestExecBatchFailsConnectionClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	results, err := conn.ExecBatch(context.Background(), []string{"insert"})
+	suite.Same(driver.ErrBadConn, err)
+	suite.Nil(results)
+}
+
 func (suite *ConnectionTestSuite) TestPrepareContextFailsClosed() {
 	conn := suite.createOpenConnection()
 	conn.IsClosed = true
@@ -185,6 +462,42 @@ func (suite *ConnectionTestSuite) TestPrepareContextSuccess() {
 	suite.NotNil(stmt)
 }
 
+func (suite *ConnectionTestSuite) TestPrepareContextWithNamedParameters() {
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "select * from t where a = ? or b = ? or c = ?",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			ParameterData: types.ParameterData{NumColumns: 3}})
+	stmt, err := suite.createOpenConnection().PrepareContext(WithNamedParameters(context.Background()), "select * from t where a = :a or b = :b or c = :a")
+	suite.NoError(err)
+	suite.Equal(utils.NamedParameters{Count: 3, Indices: map[string][]int{"a": {0, 2}, "b": {1}}}, stmt.(*Statement).namedParameters)
+}
+
+func (suite *ConnectionTestSuite) TestPrepareContextWithoutNamedParametersKeepsQuery() {
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "select t[:n] from t where a = ? and b = @b",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			ParameterData: types.ParameterData{NumColumns: 1}})
+	stmt, err := suite.createOpenConnection().PrepareContext(context.Background(), "select t[:n] from t where a = ? and b = @b")
+	suite.NoError(err)
+	suite.Nil(stmt.(*Statement).namedParameters.Indices)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestStatementNamedValuesNotEnabled() {
+	statement := &Statement{connection: suite.createOpenConnection(), columns: []types.SqlQueryColumn{{Name: "a"}}}
+	result, err := statement.ExecContext(context.Background(), []driver.NamedValue{{Name: "a", Ordinal: 1, Value: "value"}})
+	suite.EqualError(err, "E-EGOD-46: named parameters are not enabled for the statement, prepare it with a context created by connection.WithNamedParameters()")
+	suite.Nil(result)
+}
+
 func (suite *ConnectionTestSuite) TestPrepareSuccess() {
 	suite.websocketMock.SimulateOKResponse(
 		types.SqlCommand{
@@ -245,6 +558,80 @@ func (suite *ConnectionTestSuite) TestBeginFailsWithAutocommitEnabled() {
 	suite.Nil(tx)
 }
 
+func (suite *ConnectionTestSuite) TestPingSuccess() {
+	suite.websocketMock.SimulateOKResponse(types.Command{Command: "getAttributes"}, nil)
+	suite.NoError(suite.createOpenConnection().Ping(context.Background()))
+}
+
+func (suite *ConnectionTestSuite) TestPingFailsWithConnectionClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	suite.Same(driver.ErrBadConn, conn.Ping(context.Background()))
+}
+
+func (suite *ConnectionTestSuite) TestPingFailsWithWebsocketError() {
+	suite.websocketMock.SimulateWriteFails(types.Command{Command: "getAttributes"}, fmt.Errorf("mock error"))
+	suite.Same(driver.ErrBadConn, suite.createOpenConnection().Ping(context.Background()))
+}
+
+func (suite *ConnectionTestSuite) TestPingFailsWithSqlError() {
+	suite.websocketMock.SimulateErrorResponse(types.Command{Command: "getAttributes"}, mockException)
+	suite.EqualError(suite.createOpenConnection().Ping(context.Background()), mockExceptionError(mockException))
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxDisablesAutocommit() {
+	suite.websocketMock.SimulateOKResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"},
+		Attributes: types.Attributes{Autocommit: utils.BoolToPtr(false)}}, nil)
+	conn := suite.createOpenConnection()
+	conn.Config.Autocommit = true
+	conn.initSessionState()
+	tx, err := conn.BeginTx(context.Background(), driver.TxOptions{})
+	suite.NoError(err)
+	suite.Equal(&types.Attributes{Autocommit: utils.BoolToPtr(true)}, tx.(*Transaction).restoreAttributes)
+	suite.False(conn.getSessionState().autocommit)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxWithoutAutocommit() {
+	conn := suite.createOpenConnection()
+	tx, err := conn.BeginTx(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelSerializable)})
+	suite.NoError(err)
+	suite.Nil(tx.(*Transaction).restoreAttributes)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxFailsForReadOnly() {
+	tx, err := suite.createOpenConnection().BeginTx(context.Background(), driver.TxOptions{ReadOnly: true})
+	suite.EqualError(err, "E-EGOD-45: read-only transactions are not supported by Exasol")
+	suite.Nil(tx)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxFailsWithUnsupportedIsolationLevel() {
+	tx, err := suite.createOpenConnection().BeginTx(context.Background(), driver.TxOptions{Isolation: driver.IsolationLevel(sql.LevelReadCommitted)})
+	suite.EqualError(err, "E-EGOD-32: isolation level 'Read Committed' is not supported, Exasol only supports 'Serializable'")
+	suite.Nil(tx)
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxFailsWithConnectionClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	tx, err := conn.BeginTx(context.Background(), driver.TxOptions{})
+	suite.Same(driver.ErrBadConn, err)
+	suite.Nil(tx)
+}
+
+func (suite *ConnectionTestSuite) TestBeginTxFailsSettingAttributes() {
+	suite.websocketMock.SimulateErrorResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"},
+		Attributes: types.Attributes{Autocommit: utils.BoolToPtr(false)}}, mockException)
+	conn := suite.createOpenConnection()
+	conn.Config.Autocommit = true
+	conn.initSessionState()
+	tx, err := conn.BeginTx(context.Background(), driver.TxOptions{})
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(tx)
+}
+
 func (suite *ConnectionTestSuite) TestQueryFailsConnectionClosed() {
 	conn := suite.createOpenConnection()
 	conn.IsClosed = true
@@ -0,0 +1,146 @@
+package connection
+
+import (
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// dispatcher multiplexes requests and responses over a single websocket connection.
+//
+// Exasol answers requests strictly in the order in which they were sent. The dispatcher serializes all writes,
+// keeps a queue of requests waiting for a response and uses a single reader that hands each received message
+// to the request at the head of the queue. Responses for requests abandoned by their caller (e.g. after the
+// context was cancelled and the query was aborted) are still read and discarded, so that the next request
+// receives its own response and not a stale one.
+//
+// When the dispatcher can't guarantee that requests and responses are in sync anymore (e.g. after a failed read or
+// write), it is marked as broken and all pending and further requests fail with an error wrapping [driver.ErrBadConn].
+type dispatcher struct {
+	websocket  wsconn.WebsocketConnection
+	writeMutex sync.Mutex // serializes writes and keeps the pending queue in write order
+	mutex      sync.Mutex // guards following
+	pending    []*pendingRequest
+	reading    bool
+	err        error
+}
+
+// pendingRequest is a request that was sent and waits for its response.
+type pendingRequest struct {
+	response chan receivedMessage
+}
+
+type receivedMessage struct {
+	messageType int
+	data        []byte
+	err         error
+}
+
+func newDispatcher(websocket wsconn.WebsocketConnection) *dispatcher {
+	return &dispatcher{websocket: websocket}
+}
+
+// send writes the given message and returns the pending request that will receive the response.
+func (d *dispatcher) send(messageType int, message []byte) (*pendingRequest, error) {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+	if err := d.getError(); err != nil {
+		return nil, err
+	}
+	if err := d.write(messageType, message); err != nil {
+		return nil, err
+	}
+	request := &pendingRequest{response: make(chan receivedMessage, 1)}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.pending = append(d.pending, request)
+	if !d.reading {
+		d.reading = true
+		go d.readResponses()
+	}
+	return request, nil
+}
+
+// sendWithoutResponse writes a message for which the server does not send a response, e.g. abortQuery.
+func (d *dispatcher) sendWithoutResponse(messageType int, message []byte) error {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+	if err := d.getError(); err != nil {
+		return err
+	}
+	return d.write(messageType, message)
+}
+
+func (d *dispatcher) write(messageType int, message []byte) error {
+	err := d.websocket.WriteMessage(messageType, message)
+	if err != nil {
+		wrappedError := errors.NewRequestSendingError(err)
+		logger.ErrorLogger.Print(wrappedError)
+		// A message may have been written partially, so we can't rely on the following responses anymore.
+		d.markBroken(wrappedError)
+		return wrappedError
+	}
+	return nil
+}
+
+// readResponses reads one message for each pending request until the queue is empty.
+func (d *dispatcher) readResponses() {
+	for {
+		d.mutex.Lock()
+		if len(d.pending) == 0 {
+			d.reading = false
+			d.mutex.Unlock()
+			return
+		}
+		d.mutex.Unlock()
+
+		messageType, data, err := d.websocket.ReadMessage()
+
+		d.mutex.Lock()
+		if len(d.pending) == 0 {
+			// All pending requests already failed because the stream was marked as broken
+			d.reading = false
+			d.mutex.Unlock()
+			return
+		}
+		request := d.pending[0]
+		d.pending = d.pending[1:]
+		d.mutex.Unlock()
+
+		if err != nil {
+			wrappedError := errors.NewReceivingError(err)
+			logger.ErrorLogger.Print(wrappedError)
+			request.response <- receivedMessage{err: wrappedError}
+			d.markBroken(wrappedError)
+			continue
+		}
+		request.response <- receivedMessage{messageType: messageType, data: data}
+	}
+}
+
+// markBroken marks the stream as out of sync. All pending requests fail with the given cause.
+func (d *dispatcher) markBroken(cause error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err == nil {
+		logger.TraceLogger.Printf("Marking connection as broken: %v", cause)
+		d.err = errors.NewErrConnectionOutOfSync(cause)
+	}
+	for _, request := range d.pending {
+		request.response <- receivedMessage{err: d.err}
+	}
+	d.pending = nil
+}
+
+func (d *dispatcher) getError() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.err
+}
+
+// isBroken returns true if requests and responses are out of sync.
+func (d *dispatcher) isBroken() bool {
+	return d.getError() != nil
+}
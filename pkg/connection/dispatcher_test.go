@@ -0,0 +1,162 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestDispatcherSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
+
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *DispatcherTestSuite) TestSendAbortsQueryAndDrainsResponse() {
+	release := make(chan struct{})
+	slowQuery := types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "slow query"}
+	nextQuery := types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "next query"}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(slowQuery), nil)
+	suite.websocketMock.OnReadTextMessageAfter(release, wsconn.JsonMarshall(types.BaseResponse{Status: "error", Exception: &types.Exception{SQLCode: "R0001", Text: "query terminated"}}), nil)
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(types.Command{Command: "abortQuery"}), nil)
+	suite.websocketMock.SimulateSQLQueriesResponse(nextQuery, types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 42})
+	conn := suite.createOpenConnection()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := conn.Send(ctx, slowQuery, &types.SqlQueriesResponse{})
+	suite.ErrorIs(err, context.DeadlineExceeded)
+
+	close(release)
+	response := &types.SqlQueriesResponse{}
+	suite.NoError(conn.Send(context.Background(), nextQuery, response))
+	suite.JSONEq(`{"resultType":"rowCount","rowCount":42}`, string(response.Results[0]))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *DispatcherTestSuite) TestSendWithDoneContextDoesNotSendRequest() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := suite.createOpenConnection().Send(ctx, types.Command{Command: "getAttributes"}, nil)
+	suite.ErrorIs(err, context.Canceled)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *DispatcherTestSuite) TestSendAfterReadFailureMarksConnectionBroken() {
+	request := types.Command{Command: "getAttributes"}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.OnReadTextMessage(nil, fmt.Errorf("mock error"))
+	conn := suite.createOpenConnection()
+
+	err := conn.Send(context.Background(), request, nil)
+	suite.EqualError(err, "W-EGOD-17: could not receive data: 'mock error'")
+
+	err = conn.Send(context.Background(), request, nil)
+	suite.EqualError(err, "E-EGOD-31: requests and responses are out of sync, connection can't be used anymore: 'W-EGOD-17: could not receive data: 'mock error''")
+	suite.True(errors.Is(err, driver.ErrBadConn))
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 1)
+}
+
+func (suite *DispatcherTestSuite) TestSendAfterWriteFailureMarksConnectionBroken() {
+	request := types.Command{Command: "getAttributes"}
+	suite.websocketMock.SimulateWriteFails(request, fmt.Errorf("mock error"))
+	conn := suite.createOpenConnection()
+
+	err := conn.Send(context.Background(), request, nil)
+	suite.EqualError(err, "W-EGOD-16: could not send request: 'mock error'")
+
+	err = conn.Send(context.Background(), request, nil)
+	suite.True(errors.Is(err, driver.ErrBadConn))
+	suite.True(conn.getDispatcher().isBroken())
+}
+
+func (suite *DispatcherTestSuite) TestSendWithUndecodableResponseMarksConnectionBroken() {
+	request := types.Command{Command: "getAttributes"}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.OnReadTextMessage([]byte("invalid json"), nil)
+	conn := suite.createOpenConnection()
+
+	suite.Error(conn.Send(context.Background(), request, nil))
+	suite.True(conn.getDispatcher().isBroken())
+}
+
+func (suite *DispatcherTestSuite) TestConcurrentSendReceivesMatchingResponses() {
+	conn := suite.createOpenConnection()
+	conn.websocket = newEchoWebsocket()
+	const requestCount = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, requestCount)
+	for i := 0; i < requestCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			request := types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: fmt.Sprintf("query %d", i)}
+			response := &types.PublicKeyResponse{}
+			if err := conn.Send(context.Background(), request, response); err != nil {
+				errs <- err
+				return
+			}
+			if response.PublicKeyPem != request.SQLText {
+				errs <- fmt.Errorf("request %q received response for %q", request.SQLText, response.PublicKeyPem)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		suite.NoError(err)
+	}
+}
+
+func (suite *DispatcherTestSuite) createOpenConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345, User: "user", Password: "password", ApiVersion: 42},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
+
+// echoWebsocket answers each request in order with the request's SQL text as public key.
+type echoWebsocket struct {
+	responses chan []byte
+}
+
+func newEchoWebsocket() *echoWebsocket {
+	return &echoWebsocket{responses: make(chan []byte, 100)}
+}
+
+func (ws *echoWebsocket) WriteMessage(messageType int, data []byte) error {
+	request := &types.SqlCommand{}
+	if err := json.Unmarshal(data, request); err != nil {
+		return err
+	}
+	ws.responses <- wsconn.JsonMarshall(types.BaseResponse{Status: "ok", ResponseData: wsconn.JsonMarshall(types.PublicKeyResponse{PublicKeyPem: request.SQLText})})
+	return nil
+}
+
+func (ws *echoWebsocket) ReadMessage() (int, []byte, error) {
+	return websocket.TextMessage, <-ws.responses, nil
+}
+
+func (ws *echoWebsocket) Close() error {
+	return nil
+}
@@ -0,0 +1,149 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/proxy"
+)
+
+type ExportStatement struct {
+	query string
+	proxy *proxy.Proxy
+	// downloadedFile is the temporary file containing the downloaded data until the statement succeeded
+	downloadedFile string
+}
+
+type exportWritersKey struct{}
+
+// WithExportWriter returns a context that makes a local EXPORT statement write the data for the given file name
+// to the writer instead of the local file system. The file name must match the name in the FILE clause.
+//
+//	ctx = connection.WithExportWriter(ctx, "data.csv", writer)
+//	result, err := db.ExecContext(ctx, "EXPORT CUSTOMERS INTO LOCAL CSV FILE 'data.csv'")
+func WithExportWriter(ctx context.Context, fileName string, writer io.Writer) context.Context {
+	writers := make(map[string]io.Writer)
+	for name, existingWriter := range exportWriters(ctx) {
+		writers[name] = existingWriter
+	}
+	writers[fileName] = writer
+	return context.WithValue(ctx, exportWritersKey{}, writers)
+}
+
+func exportWriters(ctx context.Context) map[string]io.Writer {
+	if writers, ok := ctx.Value(exportWritersKey{}).(map[string]io.Writer); ok {
+		return writers
+	}
+	return nil
+}
+
+// exportHost returns the host the connection uses, so that the EXPORT tunnel is opened to the node of the session.
+// It falls back to the hosts of the connection string if the connection was not opened via Connect.
+func (c *Connection) exportHost() string {
+	if c.host == "" {
+		return c.Config.Host
+	}
+	return c.host
+}
+
+func NewExportStatement(query string, host string, port int, encryption bool) (*ExportStatement, error) {
+	paths, err := utils.GetFilePaths(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) > 1 {
+		return nil, errors.ErrMultipleExportFiles
+	}
+	p, err := createProxy(host, port, encryption)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportStatement{query: query, proxy: p}, nil
+}
+
+func (e *ExportStatement) GetUpdatedQuery() string {
+	return utils.UpdateExportQuery(e.query, utils.ProxyLocation(e.proxy.Host, e.proxy.Port, e.proxy.PublicKey))
+}
+
+func (e *ExportStatement) Close() {
+	e.proxy.Close()
+}
+
+// DownloadFile receives the exported data. Data for a file registered with [WithExportWriter]
+// is written to the writer, otherwise it is written to a temporary file next to the local file.
+// Call [ExportStatement.MoveDownloadedFile] after the statement succeeded to replace the local file.
+func (e *ExportStatement) DownloadFile(ctx context.Context) error {
+	path, err := e.localPath()
+	if err != nil {
+		return err
+	}
+	if writer, ok := exportWriters(ctx)[path]; ok {
+		return e.proxy.Read(ctx, writer)
+	}
+	// Don't truncate an existing file in case the statement fails
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.NewCouldNotCreateFile(path, err)
+	}
+	err = file.Chmod(0o644)
+	if err == nil {
+		err = e.proxy.Read(ctx, file)
+	}
+	if closeErr := file.Close(); closeErr != nil {
+		logger.ErrorLogger.Printf("Failed to close file %q: %v", file.Name(), closeErr)
+		if err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		removeFile(file.Name())
+		return err
+	}
+	e.downloadedFile = file.Name()
+	return nil
+}
+
+// MoveDownloadedFile replaces the local file with the data received by [ExportStatement.DownloadFile].
+func (e *ExportStatement) MoveDownloadedFile() error {
+	if e.downloadedFile == "" {
+		return nil
+	}
+	path, err := e.localPath()
+	if err == nil {
+		err = os.Rename(e.downloadedFile, path)
+	}
+	if err != nil {
+		removeFile(e.downloadedFile)
+		e.downloadedFile = ""
+		return errors.NewCouldNotCreateFile(path, err)
+	}
+	e.downloadedFile = ""
+	return nil
+}
+
+// RemoveDownloadedFile removes the data received by [ExportStatement.DownloadFile], e.g. after the statement failed.
+func (e *ExportStatement) RemoveDownloadedFile() {
+	if e.downloadedFile != "" {
+		removeFile(e.downloadedFile)
+		e.downloadedFile = ""
+	}
+}
+
+func (e *ExportStatement) localPath() (string, error) {
+	paths, err := utils.GetFilePaths(e.query)
+	if err != nil {
+		return "", err
+	}
+	return paths[0], nil
+}
+
+func removeFile(path string) {
+	if err := os.Remove(path); err != nil {
+		logger.ErrorLogger.Printf("Failed to remove file %q: %v", path, err)
+	}
+}
@@ -0,0 +1,214 @@
+package connection
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExportTestSuite struct {
+	suite.Suite
+	listener      net.Listener
+	response      chan string
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
+
+func (suite *ExportTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.listener = listener
+	suite.response = make(chan string, 1)
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *ExportTestSuite) TearDownTest() {
+	suite.listener.Close()
+}
+
+func (suite *ExportTestSuite) TestWithExportWriter() {
+	ctx := WithExportWriter(context.Background(), "a.csv", &bytes.Buffer{})
+	ctx = WithExportWriter(ctx, "b.csv", &bytes.Buffer{})
+	suite.Len(exportWriters(ctx), 2)
+	suite.Nil(exportWriters(context.Background()))
+}
+
+func (suite *ExportTestSuite) TestNewExportStatementFailsForMultipleFiles() {
+	statement, err := NewExportStatement("EXPORT t INTO LOCAL CSV FILE 'a.csv' FILE 'b.csv'", "127.0.0.1", 1, false)
+	suite.EqualError(err, "E-EGOD-39: export into multiple local files is not supported")
+	suite.Nil(statement)
+}
+
+func (suite *ExportTestSuite) TestDownloadToWriter() {
+	go simulateExport(suite.listener, "1,a\n2,b\n", suite.response)
+	statement := suite.createExportStatement("EXPORT t INTO LOCAL CSV FILE 'data.csv'")
+	defer statement.Close()
+	suite.Equal("EXPORT t INTO CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' ", statement.GetUpdatedQuery())
+
+	var buffer bytes.Buffer
+	suite.NoError(statement.DownloadFile(WithExportWriter(context.Background(), "data.csv", &buffer)))
+	suite.Equal("1,a\n2,b\n", buffer.String())
+	suite.Equal("200 OK", <-suite.response)
+}
+
+func (suite *ExportTestSuite) TestDownloadToFile() {
+	go simulateExport(suite.listener, "1,a\n", suite.response)
+	path := filepath.Join(suite.T().TempDir(), "data.csv")
+	statement := suite.createExportStatement(fmt.Sprintf("EXPORT t INTO LOCAL CSV FILE '%s'", path))
+	defer statement.Close()
+
+	suite.NoError(statement.DownloadFile(context.Background()))
+	suite.NoFileExists(path)
+	suite.NoError(statement.MoveDownloadedFile())
+	content, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Equal("1,a\n", string(content))
+	suite.assertDirectoryContains(filepath.Dir(path), "data.csv")
+}
+
+func (suite *ExportTestSuite) TestRemoveDownloadedFileKeepsExistingFile() {
+	go simulateExport(suite.listener, "1,a\n", suite.response)
+	path := filepath.Join(suite.T().TempDir(), "data.csv")
+	suite.Require().NoError(os.WriteFile(path, []byte("existing"), 0o600))
+	statement := suite.createExportStatement(fmt.Sprintf("EXPORT t INTO LOCAL CSV FILE '%s'", path))
+	defer statement.Close()
+
+	suite.NoError(statement.DownloadFile(context.Background()))
+	statement.RemoveDownloadedFile()
+	content, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Equal("existing", string(content))
+	suite.assertDirectoryContains(filepath.Dir(path), "data.csv")
+}
+
+func (suite *ExportTestSuite) TestDownloadFailsCreatingFile() {
+	go func() { _, _ = acceptProxyConnection(suite.listener) }()
+	statement := suite.createExportStatement("EXPORT t INTO LOCAL CSV FILE '/missing/dir/data.csv'")
+	defer statement.Close()
+	suite.ErrorContains(statement.DownloadFile(context.Background()), "E-EGOD-40: could not create file '/missing/dir/data.csv'")
+}
+
+func (suite *ExportTestSuite) TestExecExport() {
+	go simulateExport(suite.listener, "1,a\n", suite.response)
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "EXPORT t INTO CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+	var buffer bytes.Buffer
+	ctx := WithExportWriter(context.Background(), "data.csv", &buffer)
+
+	result, err := suite.createConnection().ExecContext(ctx, "EXPORT t INTO LOCAL CSV FILE 'data.csv'", nil)
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(1), rowsAffected)
+	suite.Equal("1,a\n", buffer.String())
+}
+
+func (suite *ExportTestSuite) TestExecExportUsesConnectedHost() {
+	go simulateExport(suite.listener, "1,a\n", suite.response)
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "EXPORT t INTO CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+	var buffer bytes.Buffer
+	ctx := WithExportWriter(context.Background(), "data.csv", &buffer)
+	conn := suite.createConnection()
+	conn.host = conn.Config.Host
+	conn.Config.Host = "unreachable.invalid"
+
+	_, err := conn.ExecContext(ctx, "EXPORT t INTO LOCAL CSV FILE 'data.csv'", nil)
+	suite.NoError(err)
+	suite.Equal("1,a\n", buffer.String())
+}
+
+func (suite *ExportTestSuite) TestExportHostWithoutConnectedHost() {
+	conn := suite.createConnection()
+	suite.Equal(conn.Config.Host, conn.exportHost())
+}
+
+func (suite *ExportTestSuite) TestExecExportReturnsStatementError() {
+	go func() {
+		// Accept the connection but never send data
+		conn, err := acceptProxyConnection(suite.listener)
+		if err == nil {
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+	suite.websocketMock.SimulateErrorResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "EXPORT t INTO CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		mockException)
+
+	path := filepath.Join(suite.T().TempDir(), "data.csv")
+	suite.Require().NoError(os.WriteFile(path, []byte("existing"), 0o600))
+
+	result, err := suite.createConnection().ExecContext(context.Background(), fmt.Sprintf("EXPORT t INTO LOCAL CSV FILE '%s'", path), nil)
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(result)
+	content, err := os.ReadFile(path)
+	suite.NoError(err)
+	suite.Equal("existing", string(content))
+	suite.assertDirectoryContains(filepath.Dir(path), "data.csv")
+}
+
+func (suite *ExportTestSuite) assertDirectoryContains(dir string, expectedFiles ...string) {
+	entries, err := os.ReadDir(dir)
+	suite.Require().NoError(err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	suite.Equal(expectedFiles, names)
+}
+
+func (suite *ExportTestSuite) createExportStatement(query string) *ExportStatement {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	statement, err := NewExportStatement(query, address.IP.String(), address.Port, false)
+	suite.Require().NoError(err)
+	return statement
+}
+
+func (suite *ExportTestSuite) createConnection() *Connection {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	return &Connection{
+		Config:    &config.Config{Host: address.IP.String(), Port: address.Port},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
+
+// simulateExport uploads the data like the Exasol database does for an EXPORT statement and reports the response status.
+func simulateExport(listener net.Listener, data string, response chan<- string) {
+	conn, err := acceptProxyConnection(listener)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	request, _ := http.NewRequest(http.MethodPut, "/data.csv", io.NopCloser(strings.NewReader(data)))
+	request.Host = "10.0.0.1:4242"
+	request.TransferEncoding = []string{"chunked"}
+	if err := request.Write(conn); err != nil {
+		return
+	}
+	httpResponse, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		response <- err.Error()
+		return
+	}
+	response <- httpResponse.Status
+}
@@ -1,69 +1,339 @@
 package connection
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
+	"net"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
 	"github.com/exasol/exasol-driver-go/pkg/proxy"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"golang.org/x/sync/errgroup"
 )
 
+// importBlockSize is the approximate size of the blocks of rows distributed to the proxies of a parallel import.
+const importBlockSize = 1024 * 1024
+
 type ImportStatement struct {
-	query string
-	host  string
-	port  int
-	proxy *proxy.Proxy
+	query       string
+	host        string
+	port        int
+	proxies     []*proxy.Proxy
+	compression bool
+	closeOnce   sync.Once
+}
+
+type importReadersKey struct{}
+
+// WithImportReader returns a context that makes a local IMPORT statement read the data for the given file name
+// from the reader instead of the local file system. The file name must match the name in the FILE clause.
+// The caller is responsible for closing the reader after the statement finished.
+//
+//	ctx = connection.WithImportReader(ctx, "data.csv", reader)
+//	result, err := db.ExecContext(ctx, "IMPORT INTO CUSTOMERS FROM LOCAL CSV FILE 'data.csv'")
+func WithImportReader(ctx context.Context, fileName string, reader io.Reader) context.Context {
+	readers := make(map[string]io.Reader)
+	for name, existingReader := range importReaders(ctx) {
+		readers[name] = existingReader
+	}
+	readers[fileName] = reader
+	return context.WithValue(ctx, importReadersKey{}, readers)
+}
+
+func importReaders(ctx context.Context) map[string]io.Reader {
+	if readers, ok := ctx.Value(importReadersKey{}).(map[string]io.Reader); ok {
+		return readers
+	}
+	return nil
+}
+
+// importHosts returns the hosts of all cluster nodes as comma separated list, so that an IMPORT uses all nodes
+// even if the connection string contains only one of them. It falls back to the hosts of the connection string
+// if the database does not report its nodes.
+func (c *Connection) importHosts(ctx context.Context) string {
+	if c.host == "" {
+		return c.Config.Host
+	}
+	nodes, err := c.getHosts(ctx, c.host)
+	if err != nil {
+		logger.ErrorLogger.Printf("Failed to get cluster nodes for import, using hosts %q: %v", c.Config.Host, err)
+		return c.Config.Host
+	}
+	if len(nodes) == 0 {
+		return c.Config.Host
+	}
+	return strings.Join(nodes, ",")
+}
+
+// newImportStatement creates the import statement for the cluster nodes, see importHosts.
+// The nodes may report addresses that are not reachable from the client, e.g. behind NAT or a load balancer.
+// So if no proxy can be opened for the nodes, the hosts of the connection string are used instead.
+func (c *Connection) newImportStatement(ctx context.Context, query string) (*ImportStatement, error) {
+	hosts := c.importHosts(ctx)
+	statement, err := NewImportStatement(query, hosts, c.Config.Port, c.Config.Encryption, c.Config.ImportCompression)
+	if err != nil && hosts != c.Config.Host {
+		logger.ErrorLogger.Printf("Failed to open proxies to cluster nodes %q for import, using hosts %q: %v", hosts, c.Config.Host, err)
+		return NewImportStatement(query, c.Config.Host, c.Config.Port, c.Config.Encryption, c.Config.ImportCompression)
+	}
+	return statement, err
+}
+
+// getHosts asks the database for the addresses of all cluster nodes in the network of the given host.
+func (c *Connection) getHosts(ctx context.Context, host string) ([]string, error) {
+	hostIP := host
+	if net.ParseIP(host) == nil {
+		addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		hostIP = addresses[0]
+	}
+	response := &types.GetHostsResponse{}
+	err := c.Send(ctx, &types.GetHostsCommand{Command: types.Command{Command: "getHosts"}, HostIP: hostIP}, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Nodes, nil
 }
 
-func NewImportStatement(query string, host string, port int) (*ImportStatement, error) {
-	p, err := createProxy(host, port)
+// NewImportStatement opens a proxy to each host of the cluster, so that all nodes load the data in parallel.
+// Only a single proxy is used if there is only one host or if the statement skips rows at the beginning
+// of the file, because rows must not be skipped in each of the distributed parts.
+//
+// If encryption is enabled, the database connects to the proxies via TLS. The data is sent gzip compressed
+// if compression is enabled or if one of the files is already compressed, i.e. has extension .gz.
+func NewImportStatement(query string, host string, port int, encryption bool, compression bool) (*ImportStatement, error) {
+	paths, err := utils.GetFilePaths(query)
 	if err != nil {
 		return nil, err
 	}
-	err = p.StartProxy()
+	for _, path := range paths {
+		compression = compression || utils.IsCompressedFile(path)
+	}
+	hosts, err := utils.ResolveHosts(host)
 	if err != nil {
-		p.Close()
 		return nil, err
 	}
-	return &ImportStatement{query: query, host: host, port: port, proxy: p}, nil
+	var proxies []*proxy.Proxy
+	if len(hosts) == 1 || utils.HasSkipOption(query) {
+		p, err := startProxy(hosts, port, encryption)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, p)
+	} else {
+		proxies, err = startProxies(hosts, port, encryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range proxies {
+		p.Compression = compression
+	}
+	return &ImportStatement{query: query, host: host, port: port, proxies: proxies, compression: compression}, nil
 }
 
-func createProxy(host string, port int) (*proxy.Proxy, error) {
+func createProxy(host string, port int, encryption bool) (*proxy.Proxy, error) {
 	hosts, err := utils.ResolveHosts(host)
 	if err != nil {
 		return nil, err
 	}
+	return startProxy(hosts, port, encryption)
+}
+
+// startProxy opens a proxy to one of the hosts chosen at random.
+func startProxy(hosts []string, port int, encryption bool) (*proxy.Proxy, error) {
 	utils.ShuffleHosts(hosts)
-	return proxy.NewProxy(hosts, port)
+	p, err := proxy.NewProxy(hosts, port)
+	if err != nil {
+		return nil, err
+	}
+	err = p.StartProxy()
+	if err == nil && encryption {
+		err = p.StartTLS()
+	}
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// startProxies opens a proxy to each host. Unreachable hosts are skipped.
+func startProxies(hosts []string, port int, encryption bool) ([]*proxy.Proxy, error) {
+	var proxies []*proxy.Proxy
+	var lastErr error
+	for _, host := range hosts {
+		p, err := startProxy([]string{host}, port, encryption)
+		if err != nil {
+			logger.ErrorLogger.Printf("Skipping host %q for import: %v", host, err)
+			lastErr = err
+			continue
+		}
+		proxies = append(proxies, p)
+	}
+	if len(proxies) == 0 {
+		return nil, lastErr
+	}
+	return proxies, nil
 }
 
 func (i *ImportStatement) GetUpdatedQuery() string {
-	return utils.UpdateImportQuery(i.query, i.proxy.Host, i.proxy.Port)
+	proxyLocations := make([]string, 0, len(i.proxies))
+	for _, p := range i.proxies {
+		proxyLocations = append(proxyLocations, utils.ProxyLocation(p.Host, p.Port, p.PublicKey))
+	}
+	return utils.UpdateParallelImportQuery(i.query, proxyLocations, i.compression)
 }
 
+// Close closes all proxies. It is safe to call Close concurrently with an upload.
 func (i *ImportStatement) Close() {
-	i.proxy.Close()
+	i.closeOnce.Do(func() {
+		for _, p := range i.proxies {
+			p.Close()
+		}
+	})
 }
 
+// UploadFiles sends the data of all files in the statement. Data registered with [WithImportReader]
+// is read from the reader, all other files are read from the local file system.
+//
+// A single compressed file is sent as is to a single proxy. In all other cases compressed files are decompressed,
+// so that the rows of all files can be combined or distributed, and compressed again if compression is enabled.
 func (i *ImportStatement) UploadFiles(ctx context.Context) error {
 	paths, err := utils.GetFilePaths(i.query)
 	if err != nil {
 		return err
 	}
 
+	readers := importReaders(ctx)
+	var data []io.Reader
 	var files []*os.File
+	defer func() {
+		for _, file := range files {
+			if closeErr := file.Close(); closeErr != nil {
+				logger.ErrorLogger.Printf("Failed to close file %q: %v", file.Name(), closeErr)
+			}
+		}
+	}()
 	for _, path := range paths {
+		if reader, ok := readers[path]; ok {
+			data = append(data, reader)
+			continue
+		}
 		f, ferr := utils.OpenFile(path)
 		if ferr != nil {
 			return ferr
 		}
 		files = append(files, f)
+		data = append(data, f)
 	}
 
-	err = i.proxy.Write(ctx, files, utils.GetRowSeparator(i.query))
-	if err != nil {
+	progress := newUploadProgress(ctx)
+	if len(i.proxies) == 1 && len(paths) == 1 && utils.IsCompressedFile(paths[0]) {
+		fileProgress := progress.file(paths[0])
+		err = i.proxies[0].WriteCompressed(ctx, &progressReader{reader: data[0], progress: fileProgress})
+		if err == nil {
+			fileProgress.finish()
+		}
 		return err
 	}
+	for index, path := range paths {
+		if !utils.IsCompressedFile(path) {
+			continue
+		}
+		gzipReader, gzipErr := gzip.NewReader(data[index])
+		if gzipErr != nil {
+			return errors.NewCouldNotDecompressFile(path, gzipErr)
+		}
+		data[index] = gzipReader
+	}
+
+	format := rowFormat{separator: utils.GetRowSeparator(i.query), columnDelimiter: utils.GetColumnDelimiter(i.query)}
+	if len(i.proxies) == 1 {
+		return i.proxies[0].WriteContent(func(writer io.Writer) error {
+			return writeFiles(ctx, paths, data, format, writer, progress)
+		})
+	}
+	return i.uploadParallel(ctx, paths, data, format, progress)
+}
 
+// rowFormat describes how the rows of the files are separated.
+type rowFormat struct {
+	separator string
+	// columnDelimiter encloses CSV fields that may contain the row separator, it is empty for FBV files
+	columnDelimiter string
+}
+
+// writeFiles writes the rows of all files to the writer and reports the progress of each file.
+func writeFiles(ctx context.Context, paths []string, data []io.Reader, format rowFormat, writer io.Writer, progress *uploadProgress) error {
+	for index, reader := range data {
+		fileProgress := progress.file(paths[index])
+		err := proxy.WriteRows(ctx, reader, format.separator, format.columnDelimiter, &progressWriter{writer: writer, progress: fileProgress})
+		if err != nil {
+			return err
+		}
+		fileProgress.finish()
+	}
 	return nil
 }
+
+// uploadParallel splits the data into blocks of complete rows. Each proxy sends the next available block
+// until all data is sent.
+func (i *ImportStatement) uploadParallel(ctx context.Context, paths []string, data []io.Reader, format rowFormat, progress *uploadProgress) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	blocks := make(chan []byte)
+	for _, p := range i.proxies {
+		p := p
+		group.Go(func() error {
+			return p.WriteBlocks(groupCtx, blocks)
+		})
+	}
+	group.Go(func() error {
+		defer close(blocks)
+		writer := &blockWriter{ctx: groupCtx, blocks: blocks}
+		err := writeFiles(groupCtx, paths, data, format, writer, progress)
+		if err != nil {
+			return err
+		}
+		return writer.flush()
+	})
+	return group.Wait()
+}
+
+// blockWriter collects rows and passes them to the channel in blocks of about [importBlockSize] bytes.
+// Each call of Write must contain complete rows.
+type blockWriter struct {
+	ctx    context.Context
+	blocks chan<- []byte
+	buffer []byte
+}
+
+func (w *blockWriter) Write(rows []byte) (int, error) {
+	w.buffer = append(w.buffer, rows...)
+	if len(w.buffer) >= importBlockSize {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (w *blockWriter) flush() error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	select {
+	case w.blocks <- w.buffer:
+		w.buffer = nil
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
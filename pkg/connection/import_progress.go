@@ -0,0 +1,112 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// importProgressInterval is the minimum time between two progress reports for the same file.
+const importProgressInterval = 100 * time.Millisecond
+
+// ImportProgress describes the progress of uploading a file of a local IMPORT statement.
+type ImportProgress struct {
+	// File is the name of the file as given in the FILE clause.
+	File string
+	// Bytes is the number of bytes of the file sent so far. These are the compressed bytes of the file
+	// if a compressed file is sent as is, otherwise the uncompressed bytes.
+	Bytes int64
+	// Rows is the number of rows of the file sent so far. It is 0 if a compressed file is sent as is.
+	Rows int64
+	// Elapsed is the time since the upload of the statement started.
+	Elapsed time.Duration
+	// Done is true if the file was sent completely.
+	Done bool
+}
+
+// BytesPerSecond returns the average throughput since the upload started.
+func (p ImportProgress) BytesPerSecond() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+	return float64(p.Bytes) / p.Elapsed.Seconds()
+}
+
+type importProgressKey struct{}
+
+// WithImportProgress returns a context that makes local IMPORT statements report the upload progress of each file
+// to the callback. The callback is called from the goroutine uploading the data, at most every 100 milliseconds
+// for each file and once more when the file was sent completely.
+//
+//	ctx = connection.WithImportProgress(ctx, func(progress connection.ImportProgress) {
+//		fmt.Printf("%s: %d rows, %.0f bytes/s\n", progress.File, progress.Rows, progress.BytesPerSecond())
+//	})
+func WithImportProgress(ctx context.Context, callback func(ImportProgress)) context.Context {
+	return context.WithValue(ctx, importProgressKey{}, callback)
+}
+
+// uploadProgress reports the progress of all files of an IMPORT statement.
+type uploadProgress struct {
+	callback func(ImportProgress)
+	start    time.Time
+}
+
+func newUploadProgress(ctx context.Context) *uploadProgress {
+	callback, _ := ctx.Value(importProgressKey{}).(func(ImportProgress))
+	return &uploadProgress{callback: callback, start: time.Now()}
+}
+
+func (u *uploadProgress) file(path string) *fileProgress {
+	return &fileProgress{upload: u, progress: ImportProgress{File: path}}
+}
+
+type fileProgress struct {
+	upload     *uploadProgress
+	progress   ImportProgress
+	lastReport time.Time
+}
+
+func (f *fileProgress) add(bytes int, rows int) {
+	f.progress.Bytes += int64(bytes)
+	f.progress.Rows += int64(rows)
+	if f.upload.callback != nil && time.Since(f.lastReport) >= importProgressInterval {
+		f.report()
+	}
+}
+
+func (f *fileProgress) finish() {
+	f.progress.Done = true
+	if f.upload.callback != nil {
+		f.report()
+	}
+}
+
+func (f *fileProgress) report() {
+	f.lastReport = time.Now()
+	f.progress.Elapsed = f.lastReport.Sub(f.upload.start)
+	f.upload.callback(f.progress)
+}
+
+// progressWriter counts the bytes and rows written. Each call of Write must contain a single row.
+type progressWriter struct {
+	writer   io.Writer
+	progress *fileProgress
+}
+
+func (w *progressWriter) Write(row []byte) (int, error) {
+	n, err := w.writer.Write(row)
+	w.progress.add(n, 1)
+	return n, err
+}
+
+// progressReader counts the bytes read.
+type progressReader struct {
+	reader   io.Reader
+	progress *fileProgress
+}
+
+func (r *progressReader) Read(data []byte) (int, error) {
+	n, err := r.reader.Read(data)
+	r.progress.add(n, 0)
+	return n, err
+}
@@ -0,0 +1,504 @@
+package connection
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httputil"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/proxy"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+	listener      net.Listener
+	received      chan string
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestImportSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
+
+func (suite *ImportTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.listener = listener
+	suite.received = make(chan string, 1)
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	go simulateProxy(listener, suite.received)
+}
+
+func (suite *ImportTestSuite) TearDownTest() {
+	suite.listener.Close()
+}
+
+func (suite *ImportTestSuite) TestWithImportReader() {
+	ctx := WithImportReader(context.Background(), "a.csv", strings.NewReader("a"))
+	ctx = WithImportReader(ctx, "b.csv", strings.NewReader("b"))
+	suite.Len(importReaders(ctx), 2)
+	suite.Len(importReaders(WithImportReader(context.Background(), "a.csv", strings.NewReader("a"))), 1)
+}
+
+func (suite *ImportTestSuite) TestImportReadersWithoutReaders() {
+	suite.Nil(importReaders(context.Background()))
+}
+
+func (suite *ImportTestSuite) TestUploadFromReader() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'")
+	defer statement.Close()
+	suite.Equal("IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' ", statement.GetUpdatedQuery())
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n2,b\n"))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.Equal("1,a\n2,b\n", <-suite.received)
+}
+
+func (suite *ImportTestSuite) TestUploadFromReaderAndFile() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' FILE '../../testData/data.csv'")
+	defer statement.Close()
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a"))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.True(strings.HasPrefix(<-suite.received, "1,a\n"))
+}
+
+func (suite *ImportTestSuite) TestUploadFailsForMissingFile() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'missing.csv'")
+	defer statement.Close()
+	suite.EqualError(statement.UploadFiles(context.Background()), "E-EGOD-28: file 'missing.csv' not found")
+}
+
+func (suite *ImportTestSuite) TestParallelUploadDistributesCompleteRows() {
+	secondListener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer secondListener.Close()
+	secondReceived := make(chan string, 1)
+	go simulateProxy(secondListener, secondReceived)
+	statement := &ImportStatement{query: "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'",
+		proxies: []*proxy.Proxy{suite.startProxy(suite.listener), suite.startProxy(secondListener)}}
+	defer statement.Close()
+	suite.Equal("IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' AT 'http://10.0.0.1:4242' FILE 'data.csv' ", statement.GetUpdatedQuery())
+
+	var data strings.Builder
+	for row := 0; row < 200000; row++ {
+		fmt.Fprintf(&data, "%d,row %d\n", row, row)
+	}
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader(data.String()))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+
+	var rows []string
+	for _, received := range []string{<-suite.received, <-secondReceived} {
+		suite.True(received == "" || strings.HasSuffix(received, "\n"), "received incomplete row")
+		rows = append(rows, strings.Split(strings.TrimSuffix(received, "\n"), "\n")...)
+	}
+	expectedRows := strings.Split(strings.TrimSuffix(data.String(), "\n"), "\n")
+	rows = removeEmpty(rows)
+	sort.Strings(expectedRows)
+	sort.Strings(rows)
+	suite.Equal(expectedRows, rows)
+}
+
+func (suite *ImportTestSuite) TestWriteFilesPassesCompleteQuotedRows() {
+	data := "1,\"first line\nsecond \"\"line\"\"\"\n2,b\n3,\"\nlast\""
+	writer := &recordingWriter{}
+	err := writeFiles(context.Background(), []string{"data.csv"}, []io.Reader{strings.NewReader(data)},
+		rowFormat{separator: "\n", columnDelimiter: `"`}, writer, newUploadProgress(context.Background()))
+	suite.NoError(err)
+	suite.Equal([]string{"1,\"first line\nsecond \"\"line\"\"\"\n", "2,b\n", "3,\"\nlast\"\n"}, writer.writes)
+}
+
+func (suite *ImportTestSuite) TestWriteFilesSplitsFbvAtEachRowSeparator() {
+	writer := &recordingWriter{}
+	err := writeFiles(context.Background(), []string{"data.fbv"}, []io.Reader{strings.NewReader("1\"\n2\"\n")},
+		rowFormat{separator: "\n"}, writer, newUploadProgress(context.Background()))
+	suite.NoError(err)
+	suite.Equal([]string{"1\"\n", "2\"\n"}, writer.writes)
+}
+
+func (suite *ImportTestSuite) TestWriteFilesReturnsReadErrorWithoutIncompleteRow() {
+	writer := &recordingWriter{}
+	reader := io.MultiReader(strings.NewReader("a,b\nc,"), iotest.ErrReader(errors.New("connection reset")))
+	err := writeFiles(context.Background(), []string{"data.csv"}, []io.Reader{reader},
+		rowFormat{separator: "\n", columnDelimiter: `"`}, writer, newUploadProgress(context.Background()))
+	suite.EqualError(err, "failed to read data: connection reset")
+	suite.Equal([]string{"a,b\n"}, writer.writes)
+}
+
+func (suite *ImportTestSuite) TestNewImportStatementUsesSingleProxyForSkip() {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	statement, err := NewImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv' SKIP = 1", address.IP.String()+",127.0.0.1", address.Port, false, false)
+	suite.Require().NoError(err)
+	defer statement.Close()
+	suite.Len(statement.proxies, 1)
+}
+
+func (suite *ImportTestSuite) TestNewImportStatementSkipsUnreachableHosts() {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	statement, err := NewImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", address.IP.String()+",unreachable.invalid", address.Port, false, false)
+	suite.Require().NoError(err)
+	defer statement.Close()
+	suite.Len(statement.proxies, 1)
+}
+
+func (suite *ImportTestSuite) TestUploadEncrypted() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer listener.Close()
+	received := make(chan string, 1)
+	publicKey := make(chan string, 1)
+	go simulateEncryptedProxy(listener, received, publicKey)
+	address := listener.Addr().(*net.TCPAddr)
+	statement, err := NewImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", address.IP.String(), address.Port, true, false)
+	suite.Require().NoError(err)
+	defer statement.Close()
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n"))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.Equal("1,a\n", <-received)
+	suite.Equal(fmt.Sprintf("IMPORT INTO t FROM CSV AT 'https://10.0.0.1:4242' PUBLIC KEY '%s' FILE 'data.csv' ", <-publicKey), statement.GetUpdatedQuery())
+}
+
+func (suite *ImportTestSuite) TestUploadCompressed() {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	statement, err := NewImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", address.IP.String(), address.Port, false, true)
+	suite.Require().NoError(err)
+	defer statement.Close()
+	suite.Equal("IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv.gz' ", statement.GetUpdatedQuery())
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n2,b"))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.Equal("1,a\n2,b\n", decompress(suite.T(), <-suite.received))
+}
+
+func (suite *ImportTestSuite) TestUploadPassesThroughCompressedFile() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv.gz'")
+	defer statement.Close()
+	suite.Equal("IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv.gz' ", statement.GetUpdatedQuery())
+
+	compressed := compress(suite.T(), "1,a\n")
+	ctx := WithImportReader(context.Background(), "data.csv.gz", strings.NewReader(compressed))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.Equal(compressed, <-suite.received)
+}
+
+func (suite *ImportTestSuite) TestUploadCombinesCompressedAndUncompressedFiles() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv.gz' FILE 'b.csv'")
+	defer statement.Close()
+
+	ctx := WithImportReader(context.Background(), "a.csv.gz", strings.NewReader(compress(suite.T(), "1,a\n")))
+	ctx = WithImportReader(ctx, "b.csv", strings.NewReader("2,b\n"))
+	suite.NoError(statement.UploadFiles(ctx))
+	statement.Close()
+	suite.Equal("1,a\n2,b\n", decompress(suite.T(), <-suite.received))
+}
+
+func (suite *ImportTestSuite) TestUploadFailsForInvalidCompressedFile() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv.gz' FILE 'b.csv'")
+	defer statement.Close()
+
+	ctx := WithImportReader(context.Background(), "a.csv.gz", strings.NewReader("1,a\n"))
+	ctx = WithImportReader(ctx, "b.csv", strings.NewReader("2,b\n"))
+	suite.EqualError(statement.UploadFiles(ctx), "E-EGOD-42: could not decompress file 'a.csv.gz': 'unexpected EOF'")
+}
+
+func (suite *ImportTestSuite) TestUploadReportsProgress() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' FILE 'b.csv'")
+	defer statement.Close()
+
+	var progress []ImportProgress
+	ctx := WithImportProgress(context.Background(), func(p ImportProgress) {
+		progress = append(progress, p)
+	})
+	ctx = WithImportReader(ctx, "a.csv", strings.NewReader("1,a\n2,b\n"))
+	ctx = WithImportReader(ctx, "b.csv", strings.NewReader("3,c"))
+	suite.NoError(statement.UploadFiles(ctx))
+
+	var finished []ImportProgress
+	for _, p := range progress {
+		if p.Done {
+			finished = append(finished, p)
+		}
+	}
+	suite.Require().Len(finished, 2)
+	first := finished[0]
+	last := finished[1]
+	suite.Equal(ImportProgress{File: "a.csv", Bytes: 8, Rows: 2, Elapsed: first.Elapsed, Done: true}, first)
+	suite.Equal(ImportProgress{File: "b.csv", Bytes: 4, Rows: 1, Elapsed: last.Elapsed, Done: true}, last)
+	suite.LessOrEqual(first.Elapsed, last.Elapsed)
+}
+
+func (suite *ImportTestSuite) TestUploadReportsProgressOfCompressedFile() {
+	statement := suite.createImportStatement("IMPORT INTO t FROM LOCAL CSV FILE 'data.csv.gz'")
+	defer statement.Close()
+
+	var progress ImportProgress
+	compressed := compress(suite.T(), "1,a\n")
+	ctx := WithImportProgress(context.Background(), func(p ImportProgress) {
+		progress = p
+	})
+	suite.NoError(statement.UploadFiles(WithImportReader(ctx, "data.csv.gz", strings.NewReader(compressed))))
+	suite.Equal(ImportProgress{File: "data.csv.gz", Bytes: int64(len(compressed)), Elapsed: progress.Elapsed, Done: true}, progress)
+}
+
+func (suite *ImportTestSuite) TestImportProgressBytesPerSecond() {
+	suite.Equal(float64(500), ImportProgress{Bytes: 1000, Elapsed: 2 * time.Second}.BytesPerSecond())
+	suite.Equal(float64(0), ImportProgress{Bytes: 1000}.BytesPerSecond())
+}
+
+func (suite *ImportTestSuite) TestExecImportReturnsUploadError() {
+	suite.websocketMock.SimulateErrorResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		mockException)
+
+	result, err := suite.createConnection().ExecContext(context.Background(), "IMPORT INTO t FROM LOCAL CSV FILE 'missing.csv'", nil)
+	suite.EqualError(err, mockExceptionError(mockException)+"\nE-EGOD-28: file 'missing.csv' not found")
+	suite.Nil(result)
+}
+
+func (suite *ImportTestSuite) TestExecImportDoesNotWaitForBlockedReaderAfterStatementError() {
+	reader := &blockingReader{reading: make(chan struct{}), release: make(chan struct{})}
+	defer close(reader.release)
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "}), nil)
+	suite.websocketMock.OnReadTextMessageAfter(reader.reading, wsconn.JsonMarshall(types.BaseResponse{Status: "notok", Exception: &mockException}), nil)
+
+	ctx := WithImportReader(context.Background(), "data.csv", reader)
+	result, err := suite.createConnection().ExecContext(ctx, "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", nil)
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(result)
+}
+
+func (suite *ImportTestSuite) TestExecImportStopsWaitingForBlockedReaderWhenContextIsCancelled() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 2})
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	ctx = WithImportReader(ctx, "data.csv", reader)
+	result, err := suite.createConnection().ExecContext(ctx, "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", nil)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Nil(result)
+}
+
+func (suite *ImportTestSuite) TestExecImportSucceeds() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 2})
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n2,b\n"))
+	result, err := suite.createConnection().ExecContext(ctx, "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", nil)
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(2), rowsAffected)
+}
+
+func (suite *ImportTestSuite) TestExecImportUsesClusterNodes() {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	suite.websocketMock.SimulateOKResponse(
+		types.GetHostsCommand{Command: types.Command{Command: "getHosts"}, HostIP: "10.0.0.1"},
+		types.GetHostsResponse{NumNodes: 1, Nodes: []string{address.IP.String()}})
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 2})
+	conn := suite.createConnection()
+	conn.Config.Host = "unreachable.invalid"
+	conn.host = "10.0.0.1"
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n2,b\n"))
+	_, err := conn.ExecContext(ctx, "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", nil)
+	suite.NoError(err)
+	suite.Equal("1,a\n2,b\n", <-suite.received)
+}
+
+func (suite *ImportTestSuite) TestExecImportFallsBackToConfiguredHostsForUnreachableClusterNodes() {
+	suite.websocketMock.SimulateOKResponse(
+		types.GetHostsCommand{Command: types.Command{Command: "getHosts"}, HostIP: "10.0.0.1"},
+		types.GetHostsResponse{NumNodes: 1, Nodes: []string{"unreachable.invalid"}})
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "IMPORT INTO t FROM CSV AT 'http://10.0.0.1:4242' FILE 'data.csv' "},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 2})
+	conn := suite.createConnection()
+	conn.host = "10.0.0.1"
+
+	ctx := WithImportReader(context.Background(), "data.csv", strings.NewReader("1,a\n2,b\n"))
+	_, err := conn.ExecContext(ctx, "IMPORT INTO t FROM LOCAL CSV FILE 'data.csv'", nil)
+	suite.NoError(err)
+	suite.Equal("1,a\n2,b\n", <-suite.received)
+}
+
+func (suite *ImportTestSuite) TestImportHostsFallsBackToConfiguredHosts() {
+	suite.websocketMock.SimulateErrorResponse(types.GetHostsCommand{Command: types.Command{Command: "getHosts"}, HostIP: "10.0.0.1"}, mockException)
+	conn := suite.createConnection()
+	conn.host = "10.0.0.1"
+	suite.Equal(conn.Config.Host, conn.importHosts(context.Background()))
+}
+
+func (suite *ImportTestSuite) TestImportHostsWithoutConnectedHost() {
+	conn := suite.createConnection()
+	suite.Equal(conn.Config.Host, conn.importHosts(context.Background()))
+}
+
+func (suite *ImportTestSuite) createConnection() *Connection {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	return &Connection{
+		Config:    &config.Config{Host: address.IP.String(), Port: address.Port},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
+
+func (suite *ImportTestSuite) startProxy(listener net.Listener) *proxy.Proxy {
+	address := listener.Addr().(*net.TCPAddr)
+	p, err := startProxy([]string{address.IP.String()}, address.Port, false)
+	suite.Require().NoError(err)
+	return p
+}
+
+func compress(t *testing.T, data string) string {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buffer.String()
+}
+
+func decompress(t *testing.T, data string) string {
+	reader, err := gzip.NewReader(strings.NewReader(data))
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(decompressed)
+}
+
+// blockingReader blocks until it is released. It closes channel reading when Read is called.
+type blockingReader struct {
+	reading chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (r *blockingReader) Read([]byte) (int, error) {
+	r.once.Do(func() { close(r.reading) })
+	<-r.release
+	return 0, io.EOF
+}
+
+// recordingWriter records the data of each call of Write.
+type recordingWriter struct {
+	writes []string
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.writes = append(w.writes, string(data))
+	return len(data), nil
+}
+
+func removeEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func (suite *ImportTestSuite) createImportStatement(query string) *ImportStatement {
+	address := suite.listener.Addr().(*net.TCPAddr)
+	statement, err := NewImportStatement(query, address.IP.String(), address.Port, false, false)
+	suite.Require().NoError(err)
+	return statement
+}
+
+// simulateProxy answers the magic words like the Exasol proxy and collects the uploaded CSV data.
+func simulateProxy(listener net.Listener, received chan<- string) {
+	conn, err := acceptProxyConnection(listener)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	receiveUpload(conn, received)
+}
+
+// simulateEncryptedProxy works like simulateProxy via TLS and reports the fingerprint of the proxy's public key.
+func simulateEncryptedProxy(listener net.Listener, received chan<- string, publicKey chan<- string) {
+	conn, err := acceptProxyConnection(listener)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // The public key is verified by the test
+	if err := tlsConn.Handshake(); err != nil {
+		publicKey <- err.Error()
+		return
+	}
+	publicKey <- proxy.PublicKeyFingerprint(tlsConn.ConnectionState().PeerCertificates[0].RawSubjectPublicKeyInfo)
+	receiveUpload(tlsConn, received)
+}
+
+func receiveUpload(conn net.Conn, received chan<- string) {
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil || line == "\r\n" {
+			break
+		}
+	}
+	data, _ := io.ReadAll(httputil.NewChunkedReader(reader))
+	received <- string(data)
+}
+
+// acceptProxyConnection accepts a connection and answers the magic words like the Exasol proxy.
+func acceptProxyConnection(listener net.Listener) (net.Conn, error) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	magicWords := make([]byte, 12)
+	if _, err := io.ReadFull(conn, magicWords); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	response := struct {
+		Start uint32
+		Port  uint32
+		Host  [16]byte
+	}{Port: 4242}
+	copy(response.Host[:], "10.0.0.1")
+	if err := binary.Write(conn, binary.LittleEndian, response); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
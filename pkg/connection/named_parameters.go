@@ -0,0 +1,37 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+)
+
+type namedParametersKey struct{}
+
+// WithNamedParameters returns a context that enables named placeholders like `:name` and `@name` for statements
+// prepared with it. The driver then replaces them with positional placeholders before preparing the statement.
+// Without this option prepared statements are sent unmodified, so that e.g. script definitions containing
+// `@Override` or `t[:n]` are not changed.
+func WithNamedParameters(ctx context.Context) context.Context {
+	return context.WithValue(ctx, namedParametersKey{}, true)
+}
+
+func namedParametersEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(namedParametersKey{}).(bool)
+	return enabled
+}
+
+// bindArguments converts the arguments of a query to positional values.
+// Named placeholders are only replaced if the arguments contain named values, otherwise the query is not modified.
+func bindArguments(query string, args []driver.NamedValue) (string, []driver.Value, error) {
+	if !utils.HasNamedValues(args) {
+		return query, utils.PositionalValues(args), nil
+	}
+	query, parameters := utils.ReplaceNamedParameters(query)
+	values, err := parameters.Bind(args)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, values, nil
+}
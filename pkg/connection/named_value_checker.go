@@ -0,0 +1,91 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// CheckNamedValue implements the [driver.NamedValueChecker] interface. It admits all values that the prepared
+// statement converter can handle, e.g. uint64, *big.Int, time.Duration, json.RawMessage or byte arrays like UUIDs.
+// Other values implementing [driver.Valuer] are converted using method Value().
+func (c *Connection) CheckNamedValue(namedValue *driver.NamedValue) error {
+	return checkNamedValue(namedValue)
+}
+
+// CheckNamedValue implements the [driver.NamedValueChecker] interface, see [Connection.CheckNamedValue].
+func (s *Statement) CheckNamedValue(namedValue *driver.NamedValue) error {
+	return checkNamedValue(namedValue)
+}
+
+func checkNamedValue(namedValue *driver.NamedValue) error {
+	value, err := checkValue(namedValue.Value)
+	if err != nil {
+		return err
+	}
+	namedValue.Value = value
+	return nil
+}
+
+// checkValue returns values supported by convertArg unchanged and converts all other values
+// using [driver.Valuer] or [driver.DefaultParameterConverter].
+func checkValue(value any) (driver.Value, error) {
+	switch value := value.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil, nil
+		}
+	case *big.Rat:
+		if value == nil {
+			return nil, nil
+		}
+	case big.Int:
+		return &value, nil
+	case big.Rat:
+		return &value, nil
+	}
+	if isSupportedArg(value) {
+		return value, nil
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		converted, err := callValuer(valuer)
+		if err != nil {
+			return nil, err
+		}
+		if isSupportedArg(converted) {
+			return converted, nil
+		}
+		return driver.DefaultParameterConverter.ConvertValue(converted)
+	}
+	if bytes, ok := toBytes(value); ok {
+		return bytes, nil
+	}
+	if uintValue, ok := toUint64(value); ok {
+		return uintValue, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(value)
+}
+
+// callValuer calls method Value() and returns nil for nil pointers like database/sql does.
+func callValuer(valuer driver.Valuer) (driver.Value, error) {
+	if value := reflect.ValueOf(valuer); value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, nil
+	}
+	return valuer.Value()
+}
+
+func isSupportedArg(value any) bool {
+	switch value.(type) {
+	case nil, bool, string, []byte, json.Number, json.RawMessage, time.Time, time.Duration,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64,
+		*big.Int, *big.Rat, [16]byte,
+		types.Geometry, types.YearMonthInterval, types.DayToSecondInterval:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type NamedValueCheckerTestSuite struct {
+	suite.Suite
+}
+
+func TestNamedValueCheckerSuite(t *testing.T) {
+	suite.Run(t, new(NamedValueCheckerTestSuite))
+}
+
+var _ driver.NamedValueChecker = (*Connection)(nil)
+var _ driver.NamedValueChecker = (*Statement)(nil)
+
+type customValuer struct{ value string }
+
+func (v customValuer) Value() (driver.Value, error) {
+	return "custom " + v.value, nil
+}
+
+type pointerValuer struct{}
+
+func (v *pointerValuer) Value() (driver.Value, error) {
+	return "pointer", nil
+}
+
+type failingValuer struct{}
+
+func (v failingValuer) Value() (driver.Value, error) {
+	return nil, errors.New("valuer failed")
+}
+
+type nestedValuer struct{}
+
+func (v nestedValuer) Value() (driver.Value, error) {
+	return customValuer{"nested"}, nil
+}
+
+type customInt int
+type customString string
+
+func (suite *NamedValueCheckerTestSuite) TestAdmitsSupportedValues() {
+	bigInt := new(big.Int).Lsh(big.NewInt(1), 100)
+	bigRat := big.NewRat(1, 3)
+	for _, value := range []any{
+		nil, true, "text", []byte("bytes"), json.Number("1.5"), json.RawMessage(`{"a":1}`),
+		time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC), 5 * time.Second,
+		int(1), int8(1), int16(1), int32(1), int64(1), uint(1), uint8(1), uint16(1), uint32(1), uint64(math.MaxUint64),
+		float32(1.5), 1.5, bigInt, bigRat, [16]byte{1},
+		types.Geometry{Type: types.GeometryPoint}, types.YearMonthInterval{Years: 1}, types.DayToSecondInterval(time.Second),
+	} {
+		suite.Run(fmt.Sprintf("%T", value), func() {
+			namedValue := &driver.NamedValue{Ordinal: 1, Value: value}
+			suite.NoError(checkNamedValue(namedValue))
+			suite.Equal(value, namedValue.Value)
+		})
+	}
+}
+
+func (suite *NamedValueCheckerTestSuite) TestConvertsValues() {
+	text := "text"
+	var nilBigInt *big.Int
+	var nilPointerValuer *pointerValuer
+	for _, testCase := range []struct {
+		name     string
+		value    any
+		expected driver.Value
+	}{
+		{"valuer", customValuer{"value"}, "custom value"},
+		{"pointer valuer", &pointerValuer{}, "pointer"},
+		{"nil pointer valuer", nilPointerValuer, nil},
+		{"nested valuer", nestedValuer{}, "custom nested"},
+		{"big.Int value", *big.NewInt(42), big.NewInt(42)},
+		{"big.Rat value", *big.NewRat(1, 2), big.NewRat(1, 2)},
+		{"nil *big.Int", nilBigInt, nil},
+		{"uuid", uuid{0x55, 0x0e}, []byte{0x55, 0x0e, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{"named int", customInt(3), int64(3)},
+		{"named string", customString("text"), "text"},
+		{"pointer", &text, "text"},
+	} {
+		suite.Run(testCase.name, func() {
+			namedValue := &driver.NamedValue{Ordinal: 1, Value: testCase.value}
+			suite.NoError(checkNamedValue(namedValue))
+			suite.Equal(testCase.expected, namedValue.Value)
+		})
+	}
+}
+
+func (suite *NamedValueCheckerTestSuite) TestFailsForUnsupportedValues() {
+	suite.EqualError(checkNamedValue(&driver.NamedValue{Value: failingValuer{}}), "valuer failed")
+	suite.EqualError(checkNamedValue(&driver.NamedValue{Value: []int{1}}), "unsupported type []int, a slice of int")
+	suite.EqualError(checkNamedValue(&driver.NamedValue{Value: struct{}{}}), "unsupported type struct {}, a struct")
+}
+
+func (suite *NamedValueCheckerTestSuite) TestConnectionAndStatementUseChecker() {
+	for _, checker := range []driver.NamedValueChecker{&Connection{}, &Statement{}} {
+		namedValue := &driver.NamedValue{Value: customValuer{"value"}}
+		suite.NoError(checker.CheckNamedValue(namedValue))
+		suite.Equal("custom value", namedValue.Value)
+	}
+}
@@ -2,57 +2,340 @@ package connection
 
 import (
 	"database/sql/driver"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
-func convertArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
-	dataType := colType.Type
-	switch dataType {
-	case "DOUBLE":
-		switch arg := arg.(type) {
-		case int:
-			return jsonDoubleValue(float64(arg)), nil
-		case int32:
-			return jsonDoubleValue(float64(arg)), nil
-		case int64:
-			return jsonDoubleValue(float64(arg)), nil
-		case float32:
-			return jsonDoubleValue(float64(arg)), nil
-		case float64:
-			return jsonDoubleValue(arg), nil
-		default:
-			return nil, errors.NewInvalidArgType(arg, dataType)
+// argConverter converts an argument of a prepared statement to a value that can be sent for a column of a specific type.
+type argConverter func(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error)
+
+var argConverters = map[string]argConverter{
+	"BOOLEAN":                        convertBooleanArg,
+	"CHAR":                           convertStringArg,
+	"VARCHAR":                        convertStringArg,
+	"DECIMAL":                        convertDecimalArg,
+	"DOUBLE":                         convertDoubleArg,
+	"DATE":                           convertDateArg,
+	"TIMESTAMP":                      convertTimestampArg,
+	"TIMESTAMP WITH LOCAL TIME ZONE": convertTimestampArg,
+	"HASHTYPE":                       convertHashtypeArg,
+	"GEOMETRY":                       convertGeometryArg,
+	types.IntervalDayToSecond:        convertDayToSecondArg,
+	types.IntervalYearToMonth:        convertYearMonthArg,
+}
+
+// convertArg converts the argument for the column type. The database interprets values of TIMESTAMP WITH LOCAL TIME ZONE
+// columns in the session time zone, so time.Time arguments for these columns are converted to the given location.
+func convertArg(arg driver.Value, colType types.SqlQueryColumnType, location *time.Location) (interface{}, error) {
+	if arg == nil {
+		return nil, nil
+	}
+	converter, ok := argConverters[colType.Type]
+	if !ok {
+		// No need to convert other types
+		return arg, nil
+	}
+	if timeValue, ok := arg.(time.Time); ok && isLocalTimeZoneColumn(colType) {
+		arg = timeValue.In(location)
+	}
+	return converter(arg, colType)
+}
+
+func convertBooleanArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch value := arg.(type) {
+	case bool:
+		return value, nil
+	case string:
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue, nil
 		}
-	case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
-		switch arg := arg.(type) {
-		case time.Time:
-			return jsonTimestampValue(arg), nil
-		case string:
-			// We assume strings are already formatted correctly
-			return arg, nil
-		default:
-			return nil, errors.NewInvalidArgType(arg, dataType)
+	default:
+		if intValue, ok := toInt64(arg); ok && (intValue == 0 || intValue == 1) {
+			return intValue == 1, nil
 		}
-	case "DATE":
-		switch arg := arg.(type) {
-		case time.Time:
-			return jsonDateValue(arg), nil
-		case string:
-			// We assume strings are already formatted correctly
+	}
+	return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+}
+
+func convertStringArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	var value string
+	switch arg := arg.(type) {
+	case string:
+		value = arg
+	case []byte:
+		value = string(arg)
+	case json.RawMessage:
+		value = string(arg)
+	case json.Number:
+		return arg, nil
+	case *big.Int:
+		value = arg.String()
+	case *big.Rat:
+		value = formatRat(arg, colType)
+	case time.Time:
+		// Same format as the JSON encoding of time.Time
+		value = arg.Format(time.RFC3339Nano)
+	default:
+		if isNumber(arg) {
+			// The database converts numbers to strings
 			return arg, nil
-		default:
-			return nil, errors.NewInvalidArgType(arg, dataType)
 		}
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+	if colType.Size != nil && int64(utf8.RuneCountInString(value)) > *colType.Size {
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+	return value, nil
+}
+
+func convertDecimalArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	var value *big.Rat
+	switch arg := arg.(type) {
+	case string:
+		value, _ = new(big.Rat).SetString(arg)
+	case json.Number:
+		value, _ = new(big.Rat).SetString(arg.String())
+	case *big.Int:
+		value = new(big.Rat).SetInt(arg)
+	case *big.Rat:
+		if !fitsDecimal(arg, colType) {
+			return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+		}
+		return formatRat(arg, colType), nil
 	default:
-		// No need to convert other types
+		if intValue, ok := toInt64(arg); ok {
+			value = new(big.Rat).SetInt64(intValue)
+		} else if uintValue, ok := toUint64(arg); ok {
+			value = new(big.Rat).SetUint64(uintValue)
+		} else if floatValue, ok := toFloat64(arg); ok {
+			value = new(big.Rat).SetFloat64(floatValue)
+		}
+	}
+	if value == nil || !fitsDecimal(value, colType) {
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+	return arg, nil
+}
+
+// maxDecimalScale is the maximum scale of Exasol's DECIMAL type.
+const maxDecimalScale = 36
+
+// formatRat formats a rational number as decimal string with the scale of the column.
+func formatRat(value *big.Rat, colType types.SqlQueryColumnType) string {
+	if value.IsInt() {
+		return value.Num().String()
+	}
+	if colType.Scale != nil {
+		return value.FloatString(int(*colType.Scale))
+	}
+	return strings.TrimRight(value.FloatString(maxDecimalScale), "0")
+}
+
+// fitsDecimal returns true if the integer part of the value fits into the precision and scale of the column.
+// Additional fractional digits are rounded by the database.
+func fitsDecimal(value *big.Rat, colType types.SqlQueryColumnType) bool {
+	if colType.Precision == nil || colType.Scale == nil {
+		return true
+	}
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(*colType.Precision-*colType.Scale), nil)
+	return new(big.Rat).Abs(value).Cmp(new(big.Rat).SetInt(limit)) < 0
+}
+
+func convertDoubleArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	if floatValue, ok := toFloat64(arg); ok {
+		return jsonDoubleValue(floatValue), nil
+	}
+	if intValue, ok := toInt64(arg); ok {
+		return jsonDoubleValue(float64(intValue)), nil
+	}
+	if uintValue, ok := toUint64(arg); ok {
+		return jsonDoubleValue(float64(uintValue)), nil
+	}
+	switch arg := arg.(type) {
+	case *big.Int:
+		floatValue, _ := new(big.Float).SetInt(arg).Float64()
+		return jsonDoubleValue(floatValue), nil
+	case *big.Rat:
+		floatValue, _ := arg.Float64()
+		return jsonDoubleValue(floatValue), nil
+	}
+	return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+}
+
+func convertTimestampArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch arg := arg.(type) {
+	case time.Time:
+		return jsonTimestampValue(arg), nil
+	case string:
+		// We assume strings are already formatted correctly
+		return arg, nil
+	default:
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+}
+
+func convertDateArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch arg := arg.(type) {
+	case time.Time:
+		return jsonDateValue(arg), nil
+	case string:
+		// We assume strings are already formatted correctly
 		return arg, nil
+	default:
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+}
+
+// convertHashtypeArg converts byte slices and arrays like UUIDs to hex strings. Strings must contain hex digits,
+// optionally separated by dashes like in UUIDs. The size of HASHTYPE columns is the number of hex digits,
+// e.g. 32 for HASHTYPE(16 BYTE), so values must have exactly half as many bytes.
+func convertHashtypeArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	var value interface{}
+	var bytes []byte
+	if stringValue, ok := arg.(string); ok {
+		decoded, err := hex.DecodeString(strings.ReplaceAll(stringValue, "-", ""))
+		if err != nil {
+			return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+		}
+		value, bytes = stringValue, decoded
+	} else if byteValue, ok := toBytes(arg); ok {
+		value, bytes = hex.EncodeToString(byteValue), byteValue
+	} else {
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+	if colType.Size != nil && int64(hex.EncodedLen(len(bytes))) != *colType.Size {
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+	return value, nil
+}
+
+func convertGeometryArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch arg := arg.(type) {
+	case types.Geometry:
+		return arg.Value()
+	case string:
+		// We assume strings are valid WKT
+		return arg, nil
+	default:
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+}
+
+func convertDayToSecondArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch arg := arg.(type) {
+	case time.Duration:
+		return types.FormatDayToSecondInterval(arg), nil
+	case types.DayToSecondInterval:
+		return arg.String(), nil
+	case string:
+		// We assume strings are already formatted correctly
+		return arg, nil
+	default:
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+}
+
+func convertYearMonthArg(arg driver.Value, colType types.SqlQueryColumnType) (interface{}, error) {
+	switch arg := arg.(type) {
+	case types.YearMonthInterval:
+		return arg.String(), nil
+	case string:
+		// We assume strings are already formatted correctly
+		return arg, nil
+	default:
+		return nil, errors.NewInvalidArgType(arg, columnTypeName(colType))
+	}
+}
+
+// columnTypeName returns the type of the column including precision and scale or size if available,
+// e.g. "DECIMAL(18,2)", "VARCHAR(100)" or "HASHTYPE(32)".
+func columnTypeName(colType types.SqlQueryColumnType) string {
+	switch colType.Type {
+	case "DECIMAL":
+		if colType.Precision != nil && colType.Scale != nil {
+			return fmt.Sprintf("%s(%d,%d)", colType.Type, *colType.Precision, *colType.Scale)
+		}
+	case "CHAR", "VARCHAR", "HASHTYPE":
+		if colType.Size != nil {
+			return fmt.Sprintf("%s(%d)", colType.Type, *colType.Size)
+		}
+	}
+	return colType.Type
+}
+
+// toInt64 converts all signed and unsigned integer types that fit into an int64.
+func toInt64(arg driver.Value) (int64, bool) {
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, ok := arg.(time.Duration); ok {
+			return 0, false
+		}
+		return value.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(value.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+func toUint64(arg driver.Value) (uint64, bool) {
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint(), true
+	default:
+		return 0, false
+	}
+}
+
+// toFloat64 converts float32 and float64 values. NaN and infinity are not supported by Exasol.
+func toFloat64(arg driver.Value) (float64, bool) {
+	value := reflect.ValueOf(arg)
+	if value.Kind() != reflect.Float32 && value.Kind() != reflect.Float64 {
+		return 0, false
+	}
+	floatValue := value.Float()
+	if math.IsNaN(floatValue) || math.IsInf(floatValue, 0) {
+		return 0, false
+	}
+	return floatValue, true
+}
+
+func isNumber(arg driver.Value) bool {
+	if _, ok := toUint64(arg); ok {
+		return true
+	}
+	if _, ok := toInt64(arg); ok {
+		return true
+	}
+	_, ok := toFloat64(arg)
+	return ok
+}
+
+// toBytes converts byte slices and byte arrays like [16]byte or uuid.UUID.
+func toBytes(arg driver.Value) ([]byte, bool) {
+	value := reflect.ValueOf(arg)
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
 	}
+	bytes := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(bytes), value)
+	return bytes, true
 }
 
 func jsonDoubleValue(value float64) json.Marshaler {
@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
+// uuid is a byte array type like uuid.UUID of package github.com/google/uuid.
+type uuid [16]byte
+
 func TestConvertArgs(t *testing.T) {
 	berlinTimeZone, err := time.LoadLocation("Europe/Berlin")
 	if err != nil {
@@ -32,10 +36,36 @@ func TestConvertArgs(t *testing.T) {
 		{arg: 123.456, exasolType: "VARCHAR", expectedJson: `123.456`},
 		{arg: -123.456, exasolType: "VARCHAR", expectedJson: `-123.456`},
 		{arg: "text", exasolType: "CHAR", expectedJson: `"text"`},
+		{arg: []byte("text"), exasolType: "VARCHAR", expectedJson: `"text"`},
+		{arg: true, exasolType: "VARCHAR", expectedError: "E-EGOD-30: cannot convert argument 'true' of type 'bool' to 'VARCHAR' type"},
+		{arg: time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC), exasolType: "CHAR", expectedJson: `"2024-06-18T00:00:00Z"`},
+		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123000000, berlinTimeZone), exasolType: "VARCHAR", expectedJson: `"2024-06-18T17:22:13.123+02:00"`},
+		{arg: nil, exasolType: "VARCHAR", expectedJson: `null`},
+		{arg: json.RawMessage(`{"a":1}`), exasolType: "VARCHAR", expectedJson: `"{\"a\":1}"`},
+		{arg: big.NewInt(42), exasolType: "VARCHAR", expectedJson: `"42"`},
+		{arg: uint64(math.MaxUint64), exasolType: "VARCHAR", expectedJson: `18446744073709551615`},
+		{arg: nil, exasolType: "DOUBLE", expectedJson: `null`},
 
 		// BOOLEAN
 		{arg: true, exasolType: "BOOLEAN", expectedJson: `true`},
 		{arg: false, exasolType: "BOOLEAN", expectedJson: `false`},
+		{arg: "TRUE", exasolType: "BOOLEAN", expectedJson: `true`},
+		{arg: "f", exasolType: "BOOLEAN", expectedJson: `false`},
+		{arg: 1, exasolType: "BOOLEAN", expectedJson: `true`},
+		{arg: int64(0), exasolType: "BOOLEAN", expectedJson: `false`},
+		{arg: 2, exasolType: "BOOLEAN", expectedError: "E-EGOD-30: cannot convert argument '2' of type 'int' to 'BOOLEAN' type"},
+		{arg: "yes please", exasolType: "BOOLEAN", expectedError: "E-EGOD-30: cannot convert argument 'yes please' of type 'string' to 'BOOLEAN' type"},
+		{arg: 1.0, exasolType: "BOOLEAN", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'float64' to 'BOOLEAN' type"},
+
+		// HASHTYPE
+		{arg: "550e8400e29b11d4a716446655440000", exasolType: "HASHTYPE", expectedJson: `"550e8400e29b11d4a716446655440000"`},
+		{arg: "550e8400-e29b-11d4-a716-446655440000", exasolType: "HASHTYPE", expectedJson: `"550e8400-e29b-11d4-a716-446655440000"`},
+		{arg: []byte{0x55, 0x0e, 0x84, 0x00}, exasolType: "HASHTYPE", expectedJson: `"550e8400"`},
+		{arg: [16]byte{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x11, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}, exasolType: "HASHTYPE", expectedJson: `"550e8400e29b11d4a716446655440000"`},
+		{arg: uuid{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x11, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}, exasolType: "HASHTYPE", expectedJson: `"550e8400e29b11d4a716446655440000"`},
+		{arg: "not hex", exasolType: "HASHTYPE", expectedError: "E-EGOD-30: cannot convert argument 'not hex' of type 'string' to 'HASHTYPE' type"},
+		{arg: "abc", exasolType: "HASHTYPE", expectedError: "E-EGOD-30: cannot convert argument 'abc' of type 'string' to 'HASHTYPE' type"},
+		{arg: 1, exasolType: "HASHTYPE", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'HASHTYPE' type"},
 
 		// DECIMAL
 		{arg: 17, exasolType: "DECIMAL", expectedJson: `17`},
@@ -51,7 +81,14 @@ func TestConvertArgs(t *testing.T) {
 		{arg: math.MinInt64, exasolType: "DECIMAL", expectedJson: `-9223372036854775808`},
 		{arg: math.MaxFloat64, exasolType: "DECIMAL", expectedJson: `1.7976931348623157e+308`},
 		{arg: math.SmallestNonzeroFloat64, exasolType: "DECIMAL", expectedJson: `5e-324`},
-		{arg: "invalid", exasolType: "DECIMAL", expectedJson: `"invalid"`}, // No special handling for invalid values
+		{arg: "invalid", exasolType: "DECIMAL", expectedError: "E-EGOD-30: cannot convert argument 'invalid' of type 'string' to 'DECIMAL' type"},
+		{arg: "123.456", exasolType: "DECIMAL", expectedJson: `"123.456"`},
+		{arg: uint64(math.MaxUint64), exasolType: "DECIMAL", expectedJson: `18446744073709551615`},
+		{arg: true, exasolType: "DECIMAL", expectedError: "E-EGOD-30: cannot convert argument 'true' of type 'bool' to 'DECIMAL' type"},
+		{arg: math.NaN(), exasolType: "DECIMAL", expectedError: "E-EGOD-30: cannot convert argument 'NaN' of type 'float64' to 'DECIMAL' type"},
+		{arg: new(big.Int).Lsh(big.NewInt(1), 100), exasolType: "DECIMAL", expectedJson: `1267650600228229401496703205376`},
+		{arg: big.NewRat(1, 4), exasolType: "DECIMAL", expectedJson: `"0.25"`},
+		{arg: big.NewRat(-6, 3), exasolType: "DECIMAL", expectedJson: `"-2"`},
 
 		// DOUBLE
 		{arg: 123, exasolType: "DOUBLE", expectedJson: `123.0`},
@@ -76,6 +113,9 @@ func TestConvertArgs(t *testing.T) {
 		{arg: float32(-123.456), exasolType: "DOUBLE", expectedJson: `-123.45600128173828`}, // Float32 rounding error is OK
 		{arg: float64(123.456), exasolType: "DOUBLE", expectedJson: `123.456`},
 		{arg: float64(-123.456), exasolType: "DOUBLE", expectedJson: `-123.456`},
+		{arg: uint64(math.MaxUint64), exasolType: "DOUBLE", expectedJson: `18446744073709552000.0`},
+		{arg: big.NewInt(42), exasolType: "DOUBLE", expectedJson: `42.0`},
+		{arg: big.NewRat(1, 4), exasolType: "DOUBLE", expectedJson: `0.25`},
 		{arg: "invalid", exasolType: "DOUBLE", expectedError: "E-EGOD-30: cannot convert argument 'invalid' of type 'string' to 'DOUBLE' type"},
 		// TIMESTAMP
 		{arg: "some string", exasolType: "TIMESTAMP", expectedJson: `"some string"`}, // We assume strings are already formatted
@@ -85,16 +125,32 @@ func TestConvertArgs(t *testing.T) {
 		// TIMESTAMP WITH LOCAL TIME ZONE
 		{arg: "some string", exasolType: "TIMESTAMP WITH LOCAL TIME ZONE", expectedJson: `"some string"`}, // We assume strings are already formatted
 		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123456789, time.UTC), exasolType: "TIMESTAMP WITH LOCAL TIME ZONE", expectedJson: `"2024-06-18 17:22:13.123456"`},
-		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123456789, berlinTimeZone), exasolType: "TIMESTAMP WITH LOCAL TIME ZONE", expectedJson: `"2024-06-18 17:22:13.123456"`},
+		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123456789, berlinTimeZone), exasolType: "TIMESTAMP WITH LOCAL TIME ZONE", expectedJson: `"2024-06-18 15:22:13.123456"`}, // Converted to the session time zone
 		{arg: 1, exasolType: "TIMESTAMP WITH LOCAL TIME ZONE", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'TIMESTAMP WITH LOCAL TIME ZONE' type"},
 		// DATE
 		{arg: "some string", exasolType: "DATE", expectedJson: `"some string"`}, // We assume strings are already formatted
 		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123456789, time.UTC), exasolType: "DATE", expectedJson: `"2024-06-18"`},
 		{arg: time.Date(2024, time.June, 18, 17, 22, 13, 123456789, berlinTimeZone), exasolType: "DATE", expectedJson: `"2024-06-18"`},
 		{arg: 1, exasolType: "DATE", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'DATE' type"},
+
+		// INTERVAL
+		{arg: "+02 12:50:10.123", exasolType: "INTERVAL DAY TO SECOND", expectedJson: `"+02 12:50:10.123"`},
+		{arg: 36*time.Hour + 1500*time.Millisecond, exasolType: "INTERVAL DAY TO SECOND", expectedJson: `"+01 12:00:01.5"`},
+		{arg: types.DayToSecondInterval(-time.Second), exasolType: "INTERVAL DAY TO SECOND", expectedJson: `"-00 00:00:01"`},
+		{arg: 1, exasolType: "INTERVAL DAY TO SECOND", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'INTERVAL DAY TO SECOND' type"},
+		{arg: "+05-03", exasolType: "INTERVAL YEAR TO MONTH", expectedJson: `"+05-03"`},
+		{arg: types.YearMonthInterval{Years: 5, Months: 3}, exasolType: "INTERVAL YEAR TO MONTH", expectedJson: `"+05-03"`},
+		{arg: types.NewYearMonthInterval(-18), exasolType: "INTERVAL YEAR TO MONTH", expectedJson: `"-01-06"`},
+		{arg: 1, exasolType: "INTERVAL YEAR TO MONTH", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'INTERVAL YEAR TO MONTH' type"},
+
+		// GEOMETRY
+		{arg: "point(1 2)", exasolType: "GEOMETRY", expectedJson: `"point(1 2)"`},
+		{arg: types.Geometry{Type: types.GeometryPoint, Points: []types.Point{{X: 1, Y: 2.5}}}, exasolType: "GEOMETRY", expectedJson: `"POINT (1 2.5)"`},
+		{arg: types.Geometry{}, exasolType: "GEOMETRY", expectedJson: `null`},
+		{arg: 1, exasolType: "GEOMETRY", expectedError: "E-EGOD-30: cannot convert argument '1' of type 'int' to 'GEOMETRY' type"},
 	} {
 		t.Run(fmt.Sprintf("Test%02d converting %T to %s returns %q", i, testCase.arg, testCase.exasolType, testCase.expectedJson), func(t *testing.T) {
-			converted, err := convertArg(testCase.arg, types.SqlQueryColumnType{Type: testCase.exasolType})
+			converted, err := convertArg(testCase.arg, types.SqlQueryColumnType{Type: testCase.exasolType}, time.UTC)
 			if testCase.expectedError != "" {
 				if err == nil {
 					t.Errorf("Expected error %q, got nil", testCase.expectedError)
@@ -120,3 +176,89 @@ func TestConvertArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertArgsValidatesColumnMetadata(t *testing.T) {
+	decimal := func(precision, scale int64) types.SqlQueryColumnType {
+		return types.SqlQueryColumnType{Type: "DECIMAL", Precision: &precision, Scale: &scale}
+	}
+	varchar := func(size int64) types.SqlQueryColumnType {
+		return types.SqlQueryColumnType{Type: "VARCHAR", Size: &size}
+	}
+	hashtype := func(size int64) types.SqlQueryColumnType {
+		return types.SqlQueryColumnType{Type: "HASHTYPE", Size: &size}
+	}
+	for i, testCase := range []struct {
+		arg           driver.Value
+		colType       types.SqlQueryColumnType
+		expectedError string
+	}{
+		{arg: 999, colType: decimal(3, 0)},
+		{arg: -999, colType: decimal(3, 0)},
+		{arg: 1000, colType: decimal(3, 0), expectedError: "E-EGOD-30: cannot convert argument '1000' of type 'int' to 'DECIMAL(3,0)' type"},
+		{arg: uint8(100), colType: decimal(2, 0), expectedError: "E-EGOD-30: cannot convert argument '100' of type 'uint8' to 'DECIMAL(2,0)' type"},
+		{arg: 9.999, colType: decimal(3, 2)},
+		{arg: 10.0, colType: decimal(3, 2), expectedError: "E-EGOD-30: cannot convert argument '10' of type 'float64' to 'DECIMAL(3,2)' type"},
+		{arg: "-99999999999999999999999999999999999.99", colType: decimal(37, 2)},
+		{arg: "100000000000000000000000000000000000", colType: decimal(37, 2), expectedError: "E-EGOD-30: cannot convert argument '100000000000000000000000000000000000' of type 'string' to 'DECIMAL(37,2)' type"},
+		{arg: json.Number("12.5"), colType: decimal(3, 1)},
+		{arg: big.NewRat(-99999, 1000), colType: decimal(4, 2)},
+		{arg: big.NewRat(1000, 1), colType: decimal(4, 2), expectedError: "E-EGOD-30: cannot convert argument '1000/1' of type '*big.Rat' to 'DECIMAL(4,2)' type"},
+		{arg: new(big.Int).Lsh(big.NewInt(1), 100), colType: decimal(30, 0), expectedError: "E-EGOD-30: cannot convert argument '1267650600228229401496703205376' of type '*big.Int' to 'DECIMAL(30,0)' type"},
+		{arg: "text", colType: varchar(4)},
+		{arg: "äöüß", colType: varchar(4)},
+		{arg: "texts", colType: varchar(4), expectedError: "E-EGOD-30: cannot convert argument 'texts' of type 'string' to 'VARCHAR(4)' type"},
+		{arg: []byte("texts"), colType: varchar(4), expectedError: "E-EGOD-30: cannot convert argument '[116 101 120 116 115]' of type '[]uint8' to 'VARCHAR(4)' type"},
+		{arg: time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC), colType: varchar(10), expectedError: "E-EGOD-30: cannot convert argument '2024-06-18 00:00:00 +0000 UTC' of type 'time.Time' to 'VARCHAR(10)' type"},
+		{arg: "550e8400-e29b-11d4-a716-446655440000", colType: hashtype(32)},
+		{arg: uuid{0x55, 0x0e, 0x84, 0x00, 0xe2, 0x9b, 0x11, 0xd4, 0xa7, 0x16, 0x44, 0x66, 0x55, 0x44, 0x00, 0x00}, colType: hashtype(32)},
+		{arg: "550e8400", colType: hashtype(32), expectedError: "E-EGOD-30: cannot convert argument '550e8400' of type 'string' to 'HASHTYPE(32)' type"},
+		{arg: "550e8400e29b11d4a716446655440000ff", colType: hashtype(32), expectedError: "E-EGOD-30: cannot convert argument '550e8400e29b11d4a716446655440000ff' of type 'string' to 'HASHTYPE(32)' type"},
+		{arg: []byte{0x55, 0x0e, 0x84, 0x00}, colType: hashtype(32), expectedError: "E-EGOD-30: cannot convert argument '[85 14 132 0]' of type '[]uint8' to 'HASHTYPE(32)' type"},
+	} {
+		t.Run(fmt.Sprintf("Test%02d converting %T %v to %s", i, testCase.arg, testCase.arg, columnTypeName(testCase.colType)), func(t *testing.T) {
+			_, err := convertArg(testCase.arg, testCase.colType, time.UTC)
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %q", err.Error())
+				}
+			} else if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("Expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestConvertTimeUsesSessionTimeZoneForLocalTimeZoneColumns(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	localTimeZone := true
+	value := time.Date(2024, time.June, 18, 17, 22, 13, 0, time.UTC)
+	for _, testCase := range []struct {
+		colType      types.SqlQueryColumnType
+		expectedJson string
+	}{
+		{types.SqlQueryColumnType{Type: "TIMESTAMP", WithLocalTimeZone: &localTimeZone}, `"2024-06-18 19:22:13.000000"`},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP WITH LOCAL TIME ZONE"}, `"2024-06-18 19:22:13.000000"`},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP"}, `"2024-06-18 17:22:13.000000"`},
+		{types.SqlQueryColumnType{Type: "DATE"}, `"2024-06-18"`},
+	} {
+		converted, err := convertArg(value, testCase.colType, berlin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jsonValue, err := json.Marshal(converted)
+		if err != nil || string(jsonValue) != testCase.expectedJson {
+			t.Errorf("Expected %s for %s, got %s (error %v)", testCase.expectedJson, testCase.colType.Type, jsonValue, err)
+		}
+	}
+}
+
+func TestConvertRatUsesColumnScale(t *testing.T) {
+	scale := int64(2)
+	converted, err := convertArg(big.NewRat(1, 3), types.SqlQueryColumnType{Type: "DECIMAL", Scale: &scale}, time.UTC)
+	if err != nil || converted != "0.33" {
+		t.Errorf("Expected 0.33, got %v (error %v)", converted, err)
+	}
+}
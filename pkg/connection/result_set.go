@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"io"
 	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/exasol/exasol-driver-go/pkg/logger"
 	"github.com/exasol/exasol-driver-go/pkg/types"
@@ -20,36 +25,77 @@ type QueryResults struct {
 	fetchedRows     int
 	totalRowPointer int
 	rowPointer      int
+	nextResults     []json.RawMessage // results following the current one
 }
 
+// ColumnDataType returns the column type as reported by the database including details like
+// character set, fraction, SRID and local time zone that are not available via [sql.ColumnType].
+func (results *QueryResults) ColumnDataType(index int) types.SqlQueryColumnType {
+	return results.data.Columns[index].DataType
+}
+
+// ColumnTypeDatabaseTypeName implements the [driver.RowsColumnTypeDatabaseTypeName] interface.
+// Timestamps with local time zone are reported as "TIMESTAMP WITH LOCAL TIME ZONE".
 func (results *QueryResults) ColumnTypeDatabaseTypeName(index int) string {
-	return results.data.Columns[index].DataType.Type
+	dataType := results.data.Columns[index].DataType
+	if dataType.Type == "TIMESTAMP" && isLocalTimeZoneColumn(dataType) {
+		return "TIMESTAMP WITH LOCAL TIME ZONE"
+	}
+	return dataType.Type
 }
 
+// ColumnTypePrecisionScale implements the [driver.RowsColumnTypePrecisionScale] interface. It returns precision
+// and scale of DECIMAL columns. For TIMESTAMP and INTERVAL DAY TO SECOND columns the scale is the number of
+// fractional second digits, the precision is the number of day digits of intervals and 0 for timestamps.
 func (results *QueryResults) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
-	if results.data.Columns[index].DataType.Precision != nil && results.data.Columns[index].DataType.Scale != nil {
-		return *results.data.Columns[index].DataType.Precision, *results.data.Columns[index].DataType.Scale, true
+	dataType := results.data.Columns[index].DataType
+	if dataType.Precision != nil && dataType.Scale != nil {
+		return *dataType.Precision, *dataType.Scale, true
+	}
+	if dataType.Fraction != nil {
+		if dataType.Precision != nil {
+			precision = *dataType.Precision
+		}
+		return precision, int64(*dataType.Fraction), true
 	}
 	return 0, 0, false
 }
 
+// ColumnTypeNullable implements the [driver.RowsColumnTypeNullable] interface.
+// The database does not report the nullability of result columns, and even columns of NOT NULL table columns
+// can contain NULL values e.g. in outer joins. So all columns are reported as nullable like in previous versions.
 func (results *QueryResults) ColumnTypeNullable(index int) (nullable, ok bool) {
 	return true, true
 }
 
+// ColumnTypeScanType implements the [driver.RowsColumnTypeScanType] interface.
 func (results *QueryResults) ColumnTypeScanType(index int) reflect.Type {
 	switch results.ColumnTypeDatabaseTypeName(index) {
-	case "VARCHAR", "CHAR", "GEOMETRY", "HASHTYPE", "INTERVAL DAY TO SECOND", "INTERVAL YEAR TO MONTH":
+	case "VARCHAR", "CHAR", "HASHTYPE":
 		return reflect.TypeOf(sql.RawBytes{})
+	case "GEOMETRY", types.IntervalDayToSecond, types.IntervalYearToMonth:
+		return reflect.TypeOf(sql.NullString{})
 	case "BOOLEAN":
 		return reflect.TypeOf(sql.NullBool{})
 	case "DOUBLE":
 		return reflect.TypeOf(sql.NullFloat64{})
+	case "DECIMAL":
+		if isInt64Column(results.data.Columns[index].DataType) {
+			return reflect.TypeOf(sql.NullInt64{})
+		}
+		return reflect.TypeOf(sql.NullString{})
+	case "DATE", "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+		if results.con.Config.ParseTime {
+			return reflect.TypeOf(sql.NullTime{})
+		}
+		return reflect.TypeOf(sql.NullString{})
 	default:
-		return reflect.TypeOf(new(interface{}))
+		return reflect.TypeOf((*interface{})(nil)).Elem()
 	}
 }
 
+// ColumnTypeLength implements the [driver.RowsColumnTypeLength] interface. It returns the size of columns
+// with variable length like VARCHAR.
 func (results *QueryResults) ColumnTypeLength(index int) (length int64, ok bool) {
 	if results.data.Columns[index].DataType.Size != nil {
 		return *results.data.Columns[index].DataType.Size, true
@@ -65,16 +111,65 @@ func (results *QueryResults) Columns() []string {
 	return col
 }
 
+// Close closes the current result set and all result sets that were not yet iterated.
 func (results *QueryResults) Close() error {
-	if results.data.ResultSetHandle == 0 {
+	handles := results.openResultSetHandles()
+	if len(handles) == 0 {
 		return nil
 	}
 	return results.con.Send(context.Background(), &types.CloseResultSetCommand{
 		Command:          types.Command{Command: "closeResultSet"},
-		ResultSetHandles: []int{results.data.ResultSetHandle},
+		ResultSetHandles: handles,
 	}, nil)
 }
 
+func (results *QueryResults) openResultSetHandles() []int {
+	var handles []int
+	if results.data.ResultSetHandle != 0 {
+		handles = append(handles, results.data.ResultSetHandle)
+	}
+	for _, result := range results.nextResults {
+		resultSet := &types.SqlQueryResponseResultSet{}
+		if err := json.Unmarshal(result, resultSet); err == nil && resultSet.ResultSet.ResultSetHandle != 0 {
+			handles = append(handles, resultSet.ResultSet.ResultSetHandle)
+		}
+	}
+	return handles
+}
+
+// HasNextResultSet implements the [driver.RowsNextResultSet] interface.
+func (results *QueryResults) HasNextResultSet() bool {
+	return len(results.nextResults) > 0
+}
+
+// NextResultSet implements the [driver.RowsNextResultSet] interface. It closes the current result set
+// and advances to the next result. Row count results are represented by a result set with
+// a single column [RowsAffectedColumn] containing the number of affected rows.
+func (results *QueryResults) NextResultSet() error {
+	if !results.HasNextResultSet() {
+		return io.EOF
+	}
+	if results.data.ResultSetHandle != 0 {
+		err := results.con.Send(context.Background(), &types.CloseResultSetCommand{
+			Command:          types.Command{Command: "closeResultSet"},
+			ResultSetHandles: []int{results.data.ResultSetHandle},
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := toResultSetData(results.nextResults[0])
+	if err != nil {
+		return err
+	}
+	results.data = data
+	results.nextResults = results.nextResults[1:]
+	results.fetchedRows = 0
+	results.totalRowPointer = 0
+	results.rowPointer = 0
+	return nil
+}
+
 func (results *QueryResults) Next(dest []driver.Value) error {
 	if results.data.NumRows == 0 {
 		return io.EOF
@@ -104,29 +199,159 @@ func (results *QueryResults) Next(dest []driver.Value) error {
 func (results *QueryResults) getColumnValue(columnIndex int) driver.Value {
 	value := results.data.Data[columnIndex][results.rowPointer]
 	columnType := results.data.Columns[columnIndex].DataType
-	return convertValue(value, columnType)
+	if !results.con.Config.ParseTime && isTimeColumn(columnType) {
+		// Keep the format of the database unless time parsing is enabled
+		return value
+	}
+	return convertValue(value, columnType, results.con.sessionLocation())
 }
 
-// Result set data contains  values as float64 even for whole numbers. This causes an error when calling "Scan()" with an integer value.
-// As a workaround we convert the float64 to int for DECIMAL columns with scale 0.
+// Result set data is decoded with json.Decoder.UseNumber, so numbers are json.Number values. DECIMAL values
+// with scale 0 and a precision of at most 18 digits are converted to int64, all other DECIMAL values
+// to exact strings to avoid the precision loss of float64. DOUBLE values are converted to float64.
 // See https://github.com/exasol/exasol-driver-go/issues/113 for details.
-func convertValue(value any, columnType types.SqlQueryColumnType) driver.Value {
-	if isIntegerColumn(columnType) {
-		if floatValue, ok := value.(float64); ok && isIntegerValue(floatValue) {
-			return int64(floatValue)
+// DATE and TIMESTAMP values are converted to time.Time if time parsing is enabled, see convertTimeValue.
+// INTERVAL and GEOMETRY values stay strings, so that they can still be scanned into strings.
+// types.DayToSecondInterval, types.YearMonthInterval and types.Geometry parse them when scanning.
+func convertValue(value any, columnType types.SqlQueryColumnType, location *time.Location) driver.Value {
+	switch value := value.(type) {
+	case json.Number:
+		return convertNumber(value, columnType)
+	case float64:
+		if isIntegerColumn(columnType) && isIntegerValue(value) {
+			return int64(value)
 		}
+		return value
+	case string:
+		if isInt64Column(columnType) {
+			if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return intValue
+			}
+		}
+		return convertTimeValue(value, columnType, location)
+	default:
+		return value
 	}
-	return value
+}
+
+func convertNumber(value json.Number, columnType types.SqlQueryColumnType) driver.Value {
+	if columnType.Type == "DECIMAL" {
+		if isInt64Column(columnType) {
+			if intValue, err := value.Int64(); err == nil {
+				return intValue
+			}
+		}
+		return value.String()
+	}
+	if floatValue, err := value.Float64(); err == nil {
+		return floatValue
+	}
+	return value.String()
 }
 
 func isIntegerColumn(columnType types.SqlQueryColumnType) bool {
 	return columnType.Type == "DECIMAL" && columnType.Scale != nil && *columnType.Scale == 0
 }
 
+// isInt64Column returns true for DECIMAL columns whose values always fit into an int64.
+func isInt64Column(columnType types.SqlQueryColumnType) bool {
+	return isIntegerColumn(columnType) && columnType.Precision != nil && *columnType.Precision <= maxInt64Precision
+}
+
+// maxInt64Precision is the maximum number of decimal digits that always fit into an int64.
+const maxInt64Precision = 18
+
 func isIntegerValue(value float64) bool {
 	return value == math.Trunc(value)
 }
 
+const (
+	exasolDateFormat      = "2006-01-02"
+	exasolTimestampFormat = "2006-01-02 15:04:05.999999999"
+)
+
+// convertTimeValue parses DATE and TIMESTAMP values into time.Time. Values of TIMESTAMP WITH LOCAL TIME ZONE columns
+// are returned in the session time zone, all other values in UTC. The value is truncated to the fractional
+// precision of the column. Values that don't match the default date and timestamp formats,
+// e.g. because the session uses a different NLS_DATE_FORMAT, are returned unchanged.
+func convertTimeValue(value string, columnType types.SqlQueryColumnType, location *time.Location) driver.Value {
+	var layout string
+	switch columnType.Type {
+	case "DATE":
+		layout = exasolDateFormat
+	case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+		layout = exasolTimestampFormat
+	default:
+		return value
+	}
+	if !isLocalTimeZoneColumn(columnType) {
+		location = time.UTC
+	}
+	timeValue, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		logger.TraceLogger.Printf("Failed to parse %s value %q: %v", columnType.Type, value, err)
+		return value
+	}
+	if columnType.Fraction != nil && *columnType.Fraction >= 0 && *columnType.Fraction < 9 {
+		timeValue = timeValue.Truncate(time.Duration(math.Pow10(9 - *columnType.Fraction)))
+	}
+	return timeValue
+}
+
+func isTimeColumn(columnType types.SqlQueryColumnType) bool {
+	switch columnType.Type {
+	case "DATE", "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+		return true
+	default:
+		return false
+	}
+}
+
+func isLocalTimeZoneColumn(columnType types.SqlQueryColumnType) bool {
+	if columnType.WithLocalTimeZone != nil {
+		return *columnType.WithLocalTimeZone
+	}
+	return columnType.Type == "TIMESTAMP WITH LOCAL TIME ZONE"
+}
+
+// loadSessionLocation returns the location for the given Exasol session time zone, e.g. "EUROPE/BERLIN".
+// Exasol reports time zone names in upper case, so the name is also tried in the
+// case used by the IANA time zone database, e.g. "Europe/Berlin" or "Etc/GMT+1".
+func loadSessionLocation(timeZone string) (*time.Location, error) {
+	location, err := time.LoadLocation(timeZone)
+	if err == nil {
+		return location, nil
+	}
+	for _, name := range ianaTimeZoneNames(timeZone) {
+		if location, nameErr := time.LoadLocation(name); nameErr == nil {
+			return location, nil
+		}
+	}
+	return nil, err
+}
+
+func ianaTimeZoneNames(timeZone string) []string {
+	parts := strings.Split(timeZone, "/")
+	titled := make([]string, len(parts))
+	for i, part := range parts {
+		titled[i] = titleCase(part)
+	}
+	last := len(parts) - 1
+	names := []string{strings.Join(titled, "/")}
+	return append(names, strings.Join(append(titled[:last:last], strings.ToUpper(parts[last])), "/"))
+}
+
+// titleCase converts the first letter of each word to upper case and all other letters to lower case.
+func titleCase(value string) string {
+	result := []rune(strings.ToLower(value))
+	for i, r := range result {
+		if i == 0 || result[i-1] == '_' || result[i-1] == '-' {
+			result[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(result)
+}
+
 func (results *QueryResults) fetchNextRowChunk() error {
 	chunk := &types.SqlQueryResponseResultSetData{}
 	err := results.con.Send(context.Background(), &types.FetchCommand{
@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
@@ -85,15 +88,61 @@ func (suite *ResultSetTestSuite) TestColumnTypeNullable() {
 	suite.True(ok)
 }
 
+func (suite *ResultSetTestSuite) TestColumnTypePrecisionScaleTimestamp() {
+	fraction := 3
+	queryResults := QueryResults{data: &types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
+		{DataType: types.SqlQueryColumnType{Type: "TIMESTAMP", Fraction: &fraction}},
+	}}}
+	precision, scale, ok := queryResults.ColumnTypePrecisionScale(0)
+	suite.Equal(int64(0), precision)
+	suite.Equal(int64(3), scale)
+	suite.True(ok)
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypePrecisionScaleIntervalDayToSecond() {
+	dayPrecision := int64(4)
+	fraction := 6
+	queryResults := QueryResults{data: &types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
+		{DataType: types.SqlQueryColumnType{Type: "INTERVAL DAY TO SECOND", Precision: &dayPrecision, Fraction: &fraction}},
+	}}}
+	precision, scale, ok := queryResults.ColumnTypePrecisionScale(0)
+	suite.Equal(int64(4), precision)
+	suite.Equal(int64(6), scale)
+	suite.True(ok)
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeDatabaseTypeNameTimestampWithLocalTimeZone() {
+	localTimeZone := true
+	queryResults := QueryResults{data: &types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
+		{DataType: types.SqlQueryColumnType{Type: "TIMESTAMP", WithLocalTimeZone: &localTimeZone}},
+		{DataType: types.SqlQueryColumnType{Type: "TIMESTAMP"}},
+	}}, con: &Connection{Config: &config.Config{ParseTime: true}}}
+	suite.Equal("TIMESTAMP WITH LOCAL TIME ZONE", queryResults.ColumnTypeDatabaseTypeName(0))
+	suite.Equal("TIMESTAMP", queryResults.ColumnTypeDatabaseTypeName(1))
+	suite.Equal(reflect.TypeOf(sql.NullTime{}), queryResults.ColumnTypeScanType(0))
+}
+
+func (suite *ResultSetTestSuite) TestColumnDataType() {
+	size := int64(100)
+	characterSet := "UTF8"
+	dataType := types.SqlQueryColumnType{Type: "VARCHAR", Size: &size, CharacterSet: &characterSet}
+	queryResults := QueryResults{data: &types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{{DataType: dataType}}}}
+	suite.Equal(dataType, queryResults.ColumnDataType(0))
+}
+
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeVarchar() {
 	suite.assertColumnType("VARCHAR", sql.RawBytes{})
 }
 
 func (suite *ResultSetTestSuite) assertColumnType(columnType string, sqlType interface{}) {
+	suite.assertColumnTypeWithConfig(&config.Config{}, columnType, sqlType)
+}
+
+func (suite *ResultSetTestSuite) assertColumnTypeWithConfig(config *config.Config, columnType string, sqlType interface{}) {
 	data := types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
 		{DataType: types.SqlQueryColumnType{Type: columnType}},
 	}}
-	queryResults := QueryResults{data: &data}
+	queryResults := QueryResults{data: &data, con: &Connection{Config: config}}
 	suite.Equal(reflect.TypeOf(sqlType), queryResults.ColumnTypeScanType(0))
 }
 
@@ -102,7 +151,7 @@ func (suite *ResultSetTestSuite) TestColumnTypeScanTypeChar() {
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeGeometry() {
-	suite.assertColumnType("GEOMETRY", sql.RawBytes{})
+	suite.assertColumnType("GEOMETRY", sql.NullString{})
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeHashtype() {
@@ -110,11 +159,11 @@ func (suite *ResultSetTestSuite) TestColumnTypeScanTypeHashtype() {
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeIntervalDayToSecond() {
-	suite.assertColumnType("INTERVAL DAY TO SECOND", sql.RawBytes{})
+	suite.assertColumnType("INTERVAL DAY TO SECOND", sql.NullString{})
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeIntervalYearToMonth() {
-	suite.assertColumnType("INTERVAL YEAR TO MONTH", sql.RawBytes{})
+	suite.assertColumnType("INTERVAL YEAR TO MONTH", sql.NullString{})
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeBoolean() {
@@ -125,8 +174,47 @@ func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDouble() {
 	suite.assertColumnType("DOUBLE", sql.NullFloat64{})
 }
 
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeInt64Decimal() {
+	suite.assertDecimalColumnType(18, 0, sql.NullInt64{})
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeLargeDecimal() {
+	suite.assertDecimalColumnType(19, 0, sql.NullString{})
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDecimalWithScale() {
+	suite.assertDecimalColumnType(18, 2, sql.NullString{})
+}
+
+func (suite *ResultSetTestSuite) assertDecimalColumnType(precision, scale int64, sqlType interface{}) {
+	data := types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
+		{DataType: types.SqlQueryColumnType{Type: "DECIMAL", Precision: &precision, Scale: &scale}},
+	}}
+	queryResults := QueryResults{data: &data}
+	suite.Equal(reflect.TypeOf(sqlType), queryResults.ColumnTypeScanType(0))
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDate() {
+	suite.assertColumnType("DATE", sql.NullString{})
+	suite.assertColumnTypeWithConfig(&config.Config{ParseTime: true}, "DATE", sql.NullTime{})
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeTimestamp() {
+	suite.assertColumnType("TIMESTAMP", sql.NullString{})
+	suite.assertColumnTypeWithConfig(&config.Config{ParseTime: true}, "TIMESTAMP", sql.NullTime{})
+}
+
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeTimestampWithLocalTimeZone() {
+	suite.assertColumnType("TIMESTAMP WITH LOCAL TIME ZONE", sql.NullString{})
+	suite.assertColumnTypeWithConfig(&config.Config{ParseTime: true}, "TIMESTAMP WITH LOCAL TIME ZONE", sql.NullTime{})
+}
+
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDefault() {
-	suite.assertColumnType("UNKNOWN", new(interface{}))
+	data := types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{
+		{DataType: types.SqlQueryColumnType{Type: "UNKNOWN"}},
+	}}
+	queryResults := QueryResults{data: &data}
+	suite.Equal(reflect.TypeOf((*interface{})(nil)).Elem(), queryResults.ColumnTypeScanType(0))
 }
 
 func (suite *ResultSetTestSuite) TestColumnTypeLength() {
@@ -221,6 +309,31 @@ func (suite *ResultSetTestSuite) TestNextReturnsCurrentData() {
 	suite.Equal(1, queryResults.fetchedRows)
 }
 
+func (suite *ResultSetTestSuite) TestNextReturnsTimeValuesAsStrings() {
+	queryResults := suite.createTimeResultSet(false)
+	dest := make([]driver.Value, 2)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{"2024-06-18", "2024-06-18 17:22:13.123000"}, dest)
+}
+
+func (suite *ResultSetTestSuite) TestNextParsesTimeValues() {
+	queryResults := suite.createTimeResultSet(true)
+	dest := make([]driver.Value, 2)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, time.June, 18, 17, 22, 13, 123000000, time.UTC)}, dest)
+}
+
+func (suite *ResultSetTestSuite) createTimeResultSet(parseTime bool) *QueryResults {
+	queryResults := suite.createResultSet()
+	queryResults.con.Config.ParseTime = parseTime
+	queryResults.data.NumRows = 1
+	queryResults.data.NumRowsInMessage = 1
+	queryResults.data.Columns = []types.SqlQueryColumn{{DataType: types.SqlQueryColumnType{Type: "DATE"}}, {DataType: types.SqlQueryColumnType{Type: "TIMESTAMP"}}}
+	queryResults.data.Data = [][]interface{}{{"2024-06-18"}, {"2024-06-18 17:22:13.123000"}}
+	queryResults.fetchedRows = 1
+	return &queryResults
+}
+
 func (suite *ResultSetTestSuite) TestNextFetchFailsWithSqlError() {
 	queryResults := suite.createResultSet()
 	queryResults.con.Config.FetchSize = 2
@@ -277,6 +390,74 @@ func (suite *ResultSetTestSuite) TestCloseSendsCloseResultSetCommand() {
 	suite.NoError(queryResults.Close())
 }
 
+func (suite *ResultSetTestSuite) TestCloseClosesRemainingResultSets() {
+	queryResults := suite.createResultSet()
+	queryResults.data.ResultSetHandle = 17
+	queryResults.nextResults = []json.RawMessage{
+		wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1}),
+		wsconn.JsonMarshall(types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{ResultSetHandle: 18}}),
+	}
+	suite.websocketMock.SimulateOKResponse(types.CloseResultSetCommand{
+		Command:          types.Command{Command: "closeResultSet"},
+		ResultSetHandles: []int{17, 18},
+	}, nil)
+	suite.NoError(queryResults.Close())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *ResultSetTestSuite) TestHasNextResultSet() {
+	queryResults := suite.createResultSet()
+	suite.False(queryResults.HasNextResultSet())
+	queryResults.nextResults = []json.RawMessage{wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount"})}
+	suite.True(queryResults.HasNextResultSet())
+}
+
+func (suite *ResultSetTestSuite) TestNextResultSetWithoutFurtherResults() {
+	queryResults := suite.createResultSet()
+	suite.Same(io.EOF, queryResults.NextResultSet())
+}
+
+func (suite *ResultSetTestSuite) TestNextResultSetClosesCurrentResultSet() {
+	queryResults := suite.createResultSet()
+	queryResults.totalRowPointer = 2
+	queryResults.nextResults = []json.RawMessage{wsconn.JsonMarshall(types.SqlQueryResponseResultSet{ResultType: "resultSet",
+		ResultSet: types.SqlQueryResponseResultSetData{NumRows: 1, NumRowsInMessage: 1, Columns: []types.SqlQueryColumn{{Name: "col"}}, Data: [][]interface{}{{"value"}}}})}
+	suite.websocketMock.SimulateOKResponse(types.CloseResultSetCommand{
+		Command:          types.Command{Command: "closeResultSet"},
+		ResultSetHandles: []int{1},
+	}, nil)
+	suite.NoError(queryResults.NextResultSet())
+	suite.Equal([]string{"col"}, queryResults.Columns())
+	dest := make([]driver.Value, 1)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{"value"}, dest)
+	suite.Same(io.EOF, queryResults.Next(dest))
+	suite.False(queryResults.HasNextResultSet())
+}
+
+func (suite *ResultSetTestSuite) TestNextResultSetWithRowCount() {
+	queryResults := suite.createResultSet()
+	queryResults.data.ResultSetHandle = 0
+	queryResults.nextResults = []json.RawMessage{wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 42})}
+	suite.NoError(queryResults.NextResultSet())
+	suite.Equal([]string{"ROWS_AFFECTED"}, queryResults.Columns())
+	suite.Equal("DECIMAL", queryResults.ColumnTypeDatabaseTypeName(0))
+	dest := make([]driver.Value, 1)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{int64(42)}, dest)
+	suite.Same(io.EOF, queryResults.Next(dest))
+}
+
+func (suite *ResultSetTestSuite) TestNextResultSetFailsClosingResultSet() {
+	queryResults := suite.createResultSet()
+	queryResults.nextResults = []json.RawMessage{wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount"})}
+	suite.websocketMock.SimulateErrorResponse(types.CloseResultSetCommand{
+		Command:          types.Command{Command: "closeResultSet"},
+		ResultSetHandles: []int{1},
+	}, mockException)
+	suite.EqualError(queryResults.NextResultSet(), mockExceptionError(mockException))
+}
+
 func (suite *ResultSetTestSuite) TestConvertValue() {
 	createType := func(dataType string, scale int64) types.SqlQueryColumnType {
 		return types.SqlQueryColumnType{Type: dataType, Scale: &scale}
@@ -306,12 +487,134 @@ func (suite *ResultSetTestSuite) TestConvertValue() {
 		{float64(1.1), createTypeWithoutScale("DECIMAL"), float64(1.1)},
 	} {
 		suite.Run(fmt.Sprintf("TestConvertValue %d value %v type %v", i, testCase.value, testCase.columnType), func() {
-			result := convertValue(testCase.value, testCase.columnType)
+			result := convertValue(testCase.value, testCase.columnType, time.UTC)
+			suite.Equal(testCase.expectedValue, result)
+		})
+	}
+}
+
+func (suite *ResultSetTestSuite) TestConvertNumber() {
+	createType := func(dataType string, precision, scale int64) types.SqlQueryColumnType {
+		return types.SqlQueryColumnType{Type: dataType, Precision: &precision, Scale: &scale}
+	}
+	for i, testCase := range []struct {
+		value         any
+		columnType    types.SqlQueryColumnType
+		expectedValue driver.Value
+	}{
+		{json.Number("9007199254740993"), createType("DECIMAL", 18, 0), int64(9007199254740993)},
+		{json.Number("-999999999999999999"), createType("DECIMAL", 18, 0), int64(-999999999999999999)},
+		{"123456789012345678", createType("DECIMAL", 18, 0), int64(123456789012345678)},
+		{json.Number("12345678901234567890123456789012345678"), createType("DECIMAL", 38, 0), "12345678901234567890123456789012345678"},
+		{"9223372036854775808", createType("DECIMAL", 36, 0), "9223372036854775808"},
+		{json.Number("1234567890123456.78"), createType("DECIMAL", 18, 2), "1234567890123456.78"},
+		{"0.1", createType("DECIMAL", 18, 1), "0.1"},
+		{json.Number("3.3"), createType("DOUBLE", 0, 0), 3.3},
+		{json.Number("1e-45"), types.SqlQueryColumnType{Type: "DOUBLE"}, 1e-45},
+		{json.Number("1"), types.SqlQueryColumnType{Type: "DECIMAL"}, "1"},
+		{json.Number("2"), types.SqlQueryColumnType{}, float64(2)},
+	} {
+		suite.Run(fmt.Sprintf("TestConvertNumber %d value %v type %v", i, testCase.value, testCase.columnType.Type), func() {
+			result := convertValue(testCase.value, testCase.columnType, time.UTC)
+			suite.Equal(testCase.expectedValue, result)
+		})
+	}
+}
+
+func (suite *ResultSetTestSuite) TestConvertIntervalValue() {
+	for i, testCase := range []struct {
+		value         any
+		columnType    string
+		expectedValue driver.Value
+	}{
+		{"+02 12:50:10.123", "INTERVAL DAY TO SECOND", "+02 12:50:10.123"},
+		{"-00 00:00:01.500", "INTERVAL DAY TO SECOND", "-00 00:00:01.500"},
+		{"+05-03", "INTERVAL YEAR TO MONTH", "+05-03"},
+		{"invalid", "INTERVAL DAY TO SECOND", "invalid"},
+		{nil, "INTERVAL YEAR TO MONTH", nil},
+	} {
+		suite.Run(fmt.Sprintf("TestConvertIntervalValue %d value %v type %v", i, testCase.value, testCase.columnType), func() {
+			result := convertValue(testCase.value, types.SqlQueryColumnType{Type: testCase.columnType}, time.UTC)
+			suite.Equal(testCase.expectedValue, result)
+		})
+	}
+}
+
+func (suite *ResultSetTestSuite) TestConvertGeometryValue() {
+	srid := 4326
+	suite.Equal("POINT (1 2)", convertValue("POINT (1 2)", types.SqlQueryColumnType{Type: "GEOMETRY", SRID: &srid}, time.UTC))
+	suite.Equal("invalid", convertValue("invalid", types.SqlQueryColumnType{Type: "GEOMETRY"}, time.UTC))
+}
+
+func (suite *ResultSetTestSuite) TestUnmarshalResponseDataKeepsNumbers() {
+	data := &types.SqlQueryResponseResultSetData{}
+	err := unmarshalResponseData([]byte(`{"data":[[9007199254740993,12345678901234567890123456789,1.5]]}`), data)
+	suite.Require().NoError(err)
+	suite.Equal([][]interface{}{{json.Number("9007199254740993"), json.Number("12345678901234567890123456789"), json.Number("1.5")}}, data.Data)
+}
+
+func (suite *ResultSetTestSuite) TestConvertTimeValue() {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	suite.Require().NoError(err)
+	fraction := func(value int) *int { return &value }
+	localTimeZone := func(value bool) *bool { return &value }
+	for i, testCase := range []struct {
+		value         any
+		columnType    types.SqlQueryColumnType
+		expectedValue driver.Value
+	}{
+		{"2024-06-18", types.SqlQueryColumnType{Type: "DATE"}, time.Date(2024, time.June, 18, 0, 0, 0, 0, time.UTC)},
+		{"2024-06-18 17:22:13", types.SqlQueryColumnType{Type: "TIMESTAMP"}, time.Date(2024, time.June, 18, 17, 22, 13, 0, time.UTC)},
+		{"2024-06-18 17:22:13.123", types.SqlQueryColumnType{Type: "TIMESTAMP"}, time.Date(2024, time.June, 18, 17, 22, 13, 123000000, time.UTC)},
+		{"2024-06-18 17:22:13.123456789", types.SqlQueryColumnType{Type: "TIMESTAMP", Fraction: fraction(9)}, time.Date(2024, time.June, 18, 17, 22, 13, 123456789, time.UTC)},
+		{"2024-06-18 17:22:13.123456789", types.SqlQueryColumnType{Type: "TIMESTAMP", Fraction: fraction(3)}, time.Date(2024, time.June, 18, 17, 22, 13, 123000000, time.UTC)},
+		{"2024-06-18 17:22:13.999", types.SqlQueryColumnType{Type: "TIMESTAMP", Fraction: fraction(0)}, time.Date(2024, time.June, 18, 17, 22, 13, 0, time.UTC)},
+		{"2024-06-18 17:22:13.123", types.SqlQueryColumnType{Type: "TIMESTAMP", WithLocalTimeZone: localTimeZone(true)}, time.Date(2024, time.June, 18, 17, 22, 13, 123000000, berlin)},
+		{"2024-06-18 17:22:13.123", types.SqlQueryColumnType{Type: "TIMESTAMP WITH LOCAL TIME ZONE"}, time.Date(2024, time.June, 18, 17, 22, 13, 123000000, berlin)},
+		{"2024-06-18 17:22:13.123", types.SqlQueryColumnType{Type: "TIMESTAMP", WithLocalTimeZone: localTimeZone(false)}, time.Date(2024, time.June, 18, 17, 22, 13, 123000000, time.UTC)},
+		{"18.06.2024", types.SqlQueryColumnType{Type: "DATE"}, "18.06.2024"},
+		{"invalid", types.SqlQueryColumnType{Type: "TIMESTAMP"}, "invalid"},
+		{"2024-06-18", types.SqlQueryColumnType{Type: "VARCHAR"}, "2024-06-18"},
+		{nil, types.SqlQueryColumnType{Type: "DATE"}, nil},
+	} {
+		suite.Run(fmt.Sprintf("TestConvertTimeValue %d value %v type %v", i, testCase.value, testCase.columnType.Type), func() {
+			result := convertValue(testCase.value, testCase.columnType, berlin)
 			suite.Equal(testCase.expectedValue, result)
 		})
 	}
 }
 
+func (suite *ResultSetTestSuite) TestLoadSessionLocation() {
+	for _, testCase := range []struct {
+		timeZone string
+		expected string
+	}{
+		{"UTC", "UTC"},
+		{"Europe/Berlin", "Europe/Berlin"},
+		{"EUROPE/BERLIN", "Europe/Berlin"},
+		{"AMERICA/NEW_YORK", "America/New_York"},
+		{"ETC/GMT+1", "Etc/GMT+1"},
+	} {
+		suite.Run(testCase.timeZone, func() {
+			location, err := loadSessionLocation(testCase.timeZone)
+			suite.Require().NoError(err)
+			suite.Equal(testCase.expected, location.String())
+		})
+	}
+}
+
+func (suite *ResultSetTestSuite) TestSessionLocationFallsBackToUTC() {
+	for _, authResponse := range []*types.AuthResponse{nil, {}, {TimeZone: "INVALID/ZONE"}} {
+		con := &Connection{authResponse: authResponse}
+		suite.Same(time.UTC, con.sessionLocation())
+	}
+}
+
+func (suite *ResultSetTestSuite) TestSessionLocationUsesAuthResponse() {
+	con := &Connection{authResponse: &types.AuthResponse{TimeZone: "EUROPE/BERLIN"}}
+	suite.Equal("Europe/Berlin", con.sessionLocation().String())
+}
+
 func (suite *ResultSetTestSuite) createResultSet() QueryResults {
 	return QueryResults{
 		data: &types.SqlQueryResponseResultSetData{
@@ -0,0 +1,112 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// sessionState is the state of the database session as far as it is known to the driver.
+// It is initialized from the configuration during login and updated with the attributes
+// the server sends in its responses.
+type sessionState struct {
+	autocommit    bool
+	currentSchema string
+}
+
+// ResetSession implements the [driver.SessionResetter] interface.
+// It is called by database/sql before a pooled connection is reused. ResetSession rolls back uncommitted work
+// and restores autocommit and the current schema as configured in the connection string.
+func (c *Connection) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return driver.ErrBadConn
+	}
+	session := c.getSessionState()
+	if !session.autocommit {
+		if _, err := c.SimpleExec(ctx, "ROLLBACK"); err != nil {
+			return c.resetSessionFailed(err)
+		}
+	}
+	// The server reports unquoted schema names in upper case
+	if session.autocommit != c.Config.Autocommit || !strings.EqualFold(session.currentSchema, c.Config.Schema) {
+		if err := c.restoreAttributes(ctx, session); err != nil {
+			return c.resetSessionFailed(err)
+		}
+	}
+	return nil
+}
+
+func (c *Connection) resetSessionFailed(err error) error {
+	logger.ErrorLogger.Printf("Failed to reset session: %v", err)
+	// Don't reuse a connection with an unknown state
+	return driver.ErrBadConn
+}
+
+func (c *Connection) restoreAttributes(ctx context.Context, session sessionState) error {
+	logger.TraceLogger.Printf("Restoring session attributes autocommit=%t, schema=%q", c.Config.Autocommit, c.Config.Schema)
+	if c.Config.Schema == "" && session.currentSchema != "" {
+		if _, err := c.SimpleExec(ctx, "CLOSE SCHEMA"); err != nil {
+			return err
+		}
+	}
+	return c.setAttributes(ctx, types.Attributes{
+		Autocommit:    utils.BoolToPtr(c.Config.Autocommit),
+		CurrentSchema: c.Config.Schema,
+	})
+}
+
+func (c *Connection) setAttributes(ctx context.Context, attributes types.Attributes) error {
+	err := c.Send(ctx, &types.SetAttributesCommand{
+		Command:    types.Command{Command: "setAttributes"},
+		Attributes: attributes,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	// The server does not necessarily report the changed attributes in its response
+	changed := &types.SessionAttributes{Autocommit: attributes.Autocommit}
+	if attributes.CurrentSchema != "" {
+		changed.CurrentSchema = &attributes.CurrentSchema
+	}
+	c.updateSessionState(changed)
+	return nil
+}
+
+// IsValid implements the [driver.Validator] interface.
+// It returns false if the connection was closed or if the websocket connection is broken.
+func (c *Connection) IsValid() bool {
+	if c.IsClosed || c.websocket == nil {
+		return false
+	}
+	return !c.getDispatcher().isBroken()
+}
+
+func (c *Connection) initSessionState() {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	c.session = sessionState{autocommit: c.Config.Autocommit, currentSchema: c.Config.Schema}
+}
+
+func (c *Connection) getSessionState() sessionState {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	return c.session
+}
+
+// updateSessionState applies the attributes reported by the server.
+func (c *Connection) updateSessionState(attributes *types.SessionAttributes) {
+	c.sessionMutex.Lock()
+	defer c.sessionMutex.Unlock()
+	if attributes.Autocommit != nil {
+		c.session.autocommit = *attributes.Autocommit
+	}
+	if attributes.CurrentSchema != nil {
+		c.session.currentSchema = *attributes.CurrentSchema
+	}
+}
@@ -0,0 +1,141 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestSessionSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
+
+func (suite *SessionTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *SessionTestSuite) TestResetSessionWithoutChanges() {
+	conn := suite.createOpenConnection(true, "SCHEMA")
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *SessionTestSuite) TestResetSessionIgnoresSchemaCase() {
+	conn := suite.createOpenConnection(true, "schema")
+	conn.updateSessionState(&types.SessionAttributes{CurrentSchema: utils.StringToPtr("SCHEMA")})
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *SessionTestSuite) TestResetSessionRollsBackWithoutAutocommit() {
+	suite.simulateExecute("ROLLBACK")
+	conn := suite.createOpenConnection(false, "SCHEMA")
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *SessionTestSuite) TestResetSessionRestoresAutocommit() {
+	suite.simulateExecute("ROLLBACK")
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true), CurrentSchema: "SCHEMA"})
+	conn := suite.createOpenConnection(true, "SCHEMA")
+	conn.updateSessionState(&types.SessionAttributes{Autocommit: utils.BoolToPtr(false)})
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *SessionTestSuite) TestResetSessionRestoresSchema() {
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true), CurrentSchema: "SCHEMA"})
+	conn := suite.createOpenConnection(true, "SCHEMA")
+	conn.updateSessionState(&types.SessionAttributes{CurrentSchema: utils.StringToPtr("OTHER")})
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *SessionTestSuite) TestResetSessionClosesSchema() {
+	suite.simulateExecute("CLOSE SCHEMA")
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true)})
+	conn := suite.createOpenConnection(true, "")
+	conn.updateSessionState(&types.SessionAttributes{CurrentSchema: utils.StringToPtr("OTHER")})
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *SessionTestSuite) TestResetSessionUsesAttributesFromResponse() {
+	suite.websocketMock.SimulateResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "OPEN SCHEMA OTHER"},
+		types.BaseResponse{Status: "ok", ResponseData: wsconn.JsonMarshall(types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount"})}}),
+			Attributes: &types.SessionAttributes{CurrentSchema: utils.StringToPtr("OTHER")}})
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true), CurrentSchema: "SCHEMA"})
+	conn := suite.createOpenConnection(true, "SCHEMA")
+	_, err := conn.SimpleExec(context.Background(), "OPEN SCHEMA OTHER")
+	suite.NoError(err)
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *SessionTestSuite) TestResetSessionFailsWithClosedConnection() {
+	conn := suite.createOpenConnection(true, "")
+	conn.IsClosed = true
+	suite.Same(driver.ErrBadConn, conn.ResetSession(context.Background()))
+}
+
+func (suite *SessionTestSuite) TestResetSessionFailsWhenRollbackFails() {
+	suite.websocketMock.SimulateErrorResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "ROLLBACK"}, mockException)
+	conn := suite.createOpenConnection(false, "")
+	suite.Same(driver.ErrBadConn, conn.ResetSession(context.Background()))
+}
+
+func (suite *SessionTestSuite) TestIsValid() {
+	suite.True(suite.createOpenConnection(true, "").IsValid())
+}
+
+func (suite *SessionTestSuite) TestIsValidWithClosedConnection() {
+	conn := suite.createOpenConnection(true, "")
+	conn.IsClosed = true
+	suite.False(conn.IsValid())
+}
+
+func (suite *SessionTestSuite) TestIsValidWithoutWebsocket() {
+	conn := suite.createOpenConnection(true, "")
+	conn.websocket = nil
+	suite.False(conn.IsValid())
+}
+
+func (suite *SessionTestSuite) TestIsValidWithBrokenWebsocket() {
+	suite.websocketMock.SimulateWriteFails(types.Command{Command: "getAttributes"}, fmt.Errorf("mock error"))
+	conn := suite.createOpenConnection(true, "")
+	suite.Error(conn.Send(context.Background(), types.Command{Command: "getAttributes"}, nil))
+	suite.False(conn.IsValid())
+}
+
+func (suite *SessionTestSuite) simulateExecute(sql string) {
+	suite.websocketMock.SimulateSQLQueriesResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: sql},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount"})
+}
+
+func (suite *SessionTestSuite) simulateSetAttributes(attributes types.Attributes) {
+	suite.websocketMock.SimulateOKResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"}, Attributes: attributes}, nil)
+}
+
+func (suite *SessionTestSuite) createOpenConnection(autocommit bool, schema string) *Connection {
+	conn := &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345, User: "user", Password: "password", ApiVersion: 42, Autocommit: autocommit, Schema: schema},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+	conn.initSessionState()
+	return conn
+}
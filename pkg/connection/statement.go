@@ -15,14 +15,68 @@ type Statement struct {
 	statementHandle int
 	columns         []types.SqlQueryColumn
 	numInput        int
+	namedParameters utils.NamedParameters  // positions of named placeholders in the query, nil indices if not enabled
+	resultColumns   []types.SqlQueryColumn // columns of the result set returned by the query, nil if it returns no result set
 }
 
 func NewStatement(connection *Connection, response *types.CreatePreparedStatementResponse) *Statement {
-	return &Statement{connection: connection, statementHandle: response.StatementHandle, columns: response.ParameterData.Columns, numInput: response.ParameterData.NumColumns}
+	return &Statement{
+		connection:      connection,
+		statementHandle: response.StatementHandle,
+		columns:         response.ParameterData.Columns,
+		numInput:        response.ParameterData.NumColumns,
+		resultColumns:   preparedResultColumns(response),
+	}
+}
+
+// preparedResultColumns returns the result set columns the server reported when preparing the statement.
+// It returns nil if the statement does not return a result set.
+func preparedResultColumns(response *types.CreatePreparedStatementResponse) []types.SqlQueryColumn {
+	if len(response.Results) == 0 {
+		return nil
+	}
+	result := &types.SqlQueryResponseResultSet{}
+	if err := unmarshalResponseData(response.Results[0], result); err != nil {
+		logger.ErrorLogger.Printf("Failed to decode result set metadata of prepared statement: %v", err)
+		return nil
+	}
+	if result.ResultType != types.ResultTypeResultSet {
+		return nil
+	}
+	return result.ResultSet.Columns
+}
+
+// ParameterTypes returns the name and data type of each parameter of the prepared statement in the order of the placeholders.
+// Use ParameterNames to get the names of named placeholders like `:name`.
+func (s *Statement) ParameterTypes() []types.SqlQueryColumn {
+	return append([]types.SqlQueryColumn(nil), s.columns...)
+}
+
+// ParameterNames returns the placeholder name of each parameter of the prepared statement in the order of the placeholders.
+// The name is empty for positional placeholders `?`.
+func (s *Statement) ParameterNames() []string {
+	names := make([]string, len(s.columns))
+	for name, indices := range s.namedParameters.Indices {
+		for _, index := range indices {
+			if index < len(names) {
+				names[index] = name
+			}
+		}
+	}
+	return names
+}
+
+// ResultColumns returns the columns of the result set returned by the prepared statement without executing it.
+// It returns nil if the statement does not return a result set, e.g. for INSERT or UPDATE statements.
+func (s *Statement) ResultColumns() []types.SqlQueryColumn {
+	if s.resultColumns == nil {
+		return nil
+	}
+	return append([]types.SqlQueryColumn(nil), s.resultColumns...)
 }
 
 func (s *Statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	values, err := utils.NamedValuesToValues(args)
+	values, err := s.bind(args)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +87,14 @@ func (s *Statement) QueryContext(ctx context.Context, args []driver.NamedValue)
 	return ToRow(result, s.connection)
 }
 
+// bind converts the arguments to positional values. Named arguments require named parameters enabled with [WithNamedParameters].
+func (s *Statement) bind(args []driver.NamedValue) ([]driver.Value, error) {
+	if s.namedParameters.Indices == nil && utils.HasNamedValues(args) {
+		return nil, errors.ErrNamedParametersNotEnabled
+	}
+	return s.namedParameters.Bind(args)
+}
+
 func (s *Statement) Query(args []driver.Value) (driver.Rows, error) {
 	result, err := s.executePreparedStatement(context.Background(), args)
 	if err != nil {
@@ -42,7 +104,7 @@ func (s *Statement) Query(args []driver.Value) (driver.Rows, error) {
 }
 
 func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	values, err := utils.NamedValuesToValues(args)
+	values, err := s.bind(args)
 	if err != nil {
 		return nil, err
 	}
@@ -98,24 +160,18 @@ func (s *Statement) executePreparedStatement(ctx context.Context, args []driver.
 		if data[col] == nil {
 			data[col] = make([]interface{}, 0)
 		}
-		convertedArg, err := convertArg(arg, colType.DataType)
+		convertedArg, err := convertArg(arg, colType.DataType, s.connection.sessionLocation())
 		if err != nil {
 			return nil, err
 		}
 		data[col] = append(data[col], convertedArg)
 	}
+	return s.sendData(ctx, data, numRows)
+}
 
-	command := &types.ExecutePreparedStatementCommand{
-		Command:         types.Command{Command: "executePreparedStatement"},
-		StatementHandle: s.statementHandle,
-		Columns:         columns,
-		NumColumns:      len(columns),
-		NumRows:         numRows,
-		Data:            data,
-		Attributes: types.Attributes{
-			ResultSetMaxRows: s.connection.Config.ResultSetMaxRows,
-		},
-	}
+// sendData executes the prepared statement with the given column-wise data.
+func (s *Statement) sendData(ctx context.Context, data [][]interface{}, numRows int) (*types.SqlQueriesResponse, error) {
+	command := s.createExecuteCommand(data, numRows)
 	result := &types.SqlQueriesResponse{}
 	err := s.connection.Send(ctx, command, result)
 	if err != nil {
@@ -127,3 +183,17 @@ func (s *Statement) executePreparedStatement(ctx context.Context, args []driver.
 	}
 	return result, err
 }
+
+func (s *Statement) createExecuteCommand(data [][]interface{}, numRows int) *types.ExecutePreparedStatementCommand {
+	return &types.ExecutePreparedStatementCommand{
+		Command:         types.Command{Command: "executePreparedStatement"},
+		StatementHandle: s.statementHandle,
+		Columns:         s.columns,
+		NumColumns:      len(s.columns),
+		NumRows:         numRows,
+		Data:            data,
+		Attributes: types.Attributes{
+			ResultSetMaxRows: s.connection.Config.ResultSetMaxRows,
+		},
+	}
+}
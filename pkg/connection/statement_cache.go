@@ -0,0 +1,153 @@
+package connection
+
+import (
+	"container/list"
+	"context"
+	stderrors "errors"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// statementCache is a least recently used cache of prepared statement handles.
+// A statement is removed from the cache while it is executed, so that a handle is never used concurrently.
+type statementCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List // cached statements, most recently used first
+	mutex    sync.Mutex
+}
+
+type cachedStatement struct {
+	key       string
+	statement *types.CreatePreparedStatementResponse
+}
+
+func newStatementCache(capacity int) *statementCache {
+	return &statementCache{capacity: capacity, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// take removes the statement with the given key from the cache and returns it.
+// It returns nil if the cache does not contain a statement for the key.
+func (c *statementCache) take(key string) *types.CreatePreparedStatementResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.Remove(element)
+	delete(c.entries, key)
+	return element.Value.(*cachedStatement).statement
+}
+
+// put adds the statement to the cache and returns the statements that were evicted and must be closed.
+func (c *statementCache) put(key string, statement *types.CreatePreparedStatementResponse) []*types.CreatePreparedStatementResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.entries[key]; ok {
+		// The same query was prepared again while the cached statement was in use
+		return []*types.CreatePreparedStatementResponse{statement}
+	}
+	c.entries[key] = c.order.PushFront(&cachedStatement{key: key, statement: statement})
+	var evicted []*types.CreatePreparedStatementResponse
+	for c.order.Len() > c.capacity {
+		entry := c.order.Remove(c.order.Back()).(*cachedStatement)
+		delete(c.entries, entry.key)
+		evicted = append(evicted, entry.statement)
+	}
+	return evicted
+}
+
+// clear removes all statements from the cache and returns them.
+func (c *statementCache) clear() []*types.CreatePreparedStatementResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	statements := make([]*types.CreatePreparedStatementResponse, 0, c.order.Len())
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		statements = append(statements, element.Value.(*cachedStatement).statement)
+	}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	return statements
+}
+
+// len returns the number of cached statements.
+func (c *statementCache) len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+// getStatementCache returns the cache of prepared statements or nil if caching is disabled.
+func (c *Connection) getStatementCache() *statementCache {
+	if c.Config.StatementCacheSize <= 0 {
+		return nil
+	}
+	c.statementCacheMutex.Lock()
+	defer c.statementCacheMutex.Unlock()
+	if c.statementCache == nil {
+		c.statementCache = newStatementCache(c.Config.StatementCacheSize)
+	}
+	return c.statementCache
+}
+
+// statementCacheKey returns the cache key for the query. It contains the current schema because
+// unqualified object names in a prepared statement are resolved in the schema that was open when it was prepared.
+func (c *Connection) statementCacheKey(query string) string {
+	return c.getSessionState().currentSchema + "\x00" + query
+}
+
+// acquirePreparedStatement returns a cached prepared statement for the query or prepares a new one.
+// Flag cached is true if the statement was taken from the cache.
+func (c *Connection) acquirePreparedStatement(ctx context.Context, key string, query string) (statement *types.CreatePreparedStatementResponse, cached bool, err error) {
+	if cache := c.getStatementCache(); cache != nil {
+		if statement := cache.take(key); statement != nil {
+			return statement, true, nil
+		}
+	}
+	statement, err = c.createPreparedStatement(ctx, query)
+	return statement, false, err
+}
+
+// releasePreparedStatement returns a successfully executed statement to the cache or closes it if caching is disabled.
+func (c *Connection) releasePreparedStatement(ctx context.Context, key string, statement *types.CreatePreparedStatementResponse) error {
+	cache := c.getStatementCache()
+	if cache == nil {
+		return c.closePreparedStatement(ctx, statement)
+	}
+	return c.closePreparedStatements(ctx, cache.put(key, statement))
+}
+
+// discardPreparedStatement closes a statement whose execution failed if caching is enabled.
+// The statement is not returned to the cache in case the handle became invalid, e.g. because a table was dropped.
+func (c *Connection) discardPreparedStatement(ctx context.Context, statement *types.CreatePreparedStatementResponse) {
+	if c.getStatementCache() == nil {
+		return
+	}
+	if err := c.closePreparedStatement(ctx, statement); err != nil {
+		logger.ErrorLogger.Printf("Failed to close prepared statement %d: %v", statement.StatementHandle, err)
+	}
+}
+
+// closeCachedStatements closes all statements in the cache.
+func (c *Connection) closeCachedStatements(ctx context.Context) error {
+	c.statementCacheMutex.Lock()
+	cache := c.statementCache
+	c.statementCacheMutex.Unlock()
+	if cache == nil {
+		return nil
+	}
+	return c.closePreparedStatements(ctx, cache.clear())
+}
+
+func (c *Connection) closePreparedStatements(ctx context.Context, statements []*types.CreatePreparedStatementResponse) error {
+	var errs []error
+	for _, statement := range statements {
+		if err := c.closePreparedStatement(ctx, statement); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
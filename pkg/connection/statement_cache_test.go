@@ -0,0 +1,248 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatementCacheTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestStatementCacheSuite(t *testing.T) {
+	suite.Run(t, new(StatementCacheTestSuite))
+}
+
+func (suite *StatementCacheTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *StatementCacheTestSuite) TestTakeMissingStatement() {
+	cache := newStatementCache(2)
+	suite.Nil(cache.take("query"))
+}
+
+func (suite *StatementCacheTestSuite) TestTakeRemovesStatement() {
+	cache := newStatementCache(2)
+	statement := &types.CreatePreparedStatementResponse{StatementHandle: 1}
+	suite.Empty(cache.put("query", statement))
+	suite.Same(statement, cache.take("query"))
+	suite.Nil(cache.take("query"))
+	suite.Equal(0, cache.len())
+}
+
+func (suite *StatementCacheTestSuite) TestPutEvictsLeastRecentlyUsed() {
+	cache := newStatementCache(2)
+	statement1 := &types.CreatePreparedStatementResponse{StatementHandle: 1}
+	statement2 := &types.CreatePreparedStatementResponse{StatementHandle: 2}
+	statement3 := &types.CreatePreparedStatementResponse{StatementHandle: 3}
+	suite.Empty(cache.put("query1", statement1))
+	suite.Empty(cache.put("query2", statement2))
+	// Using query1 makes query2 the least recently used statement
+	suite.Empty(cache.put("query1", cache.take("query1")))
+	suite.Equal([]*types.CreatePreparedStatementResponse{statement2}, cache.put("query3", statement3))
+	suite.Equal(2, cache.len())
+	suite.Nil(cache.take("query2"))
+}
+
+func (suite *StatementCacheTestSuite) TestPutDuplicateReturnsNewStatement() {
+	cache := newStatementCache(2)
+	cached := &types.CreatePreparedStatementResponse{StatementHandle: 1}
+	duplicate := &types.CreatePreparedStatementResponse{StatementHandle: 2}
+	suite.Empty(cache.put("query", cached))
+	suite.Equal([]*types.CreatePreparedStatementResponse{duplicate}, cache.put("query", duplicate))
+	suite.Same(cached, cache.take("query"))
+}
+
+func (suite *StatementCacheTestSuite) TestClear() {
+	cache := newStatementCache(2)
+	statement1 := &types.CreatePreparedStatementResponse{StatementHandle: 1}
+	statement2 := &types.CreatePreparedStatementResponse{StatementHandle: 2}
+	cache.put("query1", statement1)
+	cache.put("query2", statement2)
+	suite.Equal([]*types.CreatePreparedStatementResponse{statement2, statement1}, cache.clear())
+	suite.Equal(0, cache.len())
+	suite.Nil(cache.take("query1"))
+}
+
+func (suite *StatementCacheTestSuite) TestCacheDisabledByDefault() {
+	suite.Nil(suite.createOpenConnection(0).getStatementCache())
+}
+
+func (suite *StatementCacheTestSuite) TestCacheKeyContainsCurrentSchema() {
+	conn := suite.createOpenConnection(2)
+	keyWithoutSchema := conn.statementCacheKey("query")
+	conn.updateSessionState(&types.SessionAttributes{CurrentSchema: utils.StringToPtr("SCHEMA")})
+	suite.NotEqual(keyWithoutSchema, conn.statementCacheKey("query"))
+}
+
+func (suite *StatementCacheTestSuite) TestQueryReusesCachedStatement() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.simulateExecutePreparedStatement(1)
+	suite.simulateExecutePreparedStatement(1)
+	conn := suite.createOpenConnection(2)
+
+	for i := 0; i < 2; i++ {
+		rows, err := conn.QueryContext(context.Background(), "query", []driver.NamedValue{{Ordinal: 1, Value: "value"}})
+		suite.NoError(err)
+		suite.NotNil(rows)
+	}
+	suite.Equal(1, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestExecClosesEvictedStatement() {
+	suite.simulateCreatePreparedStatement("query1", 1)
+	suite.simulateExecutePreparedStatement(1)
+	suite.simulateCreatePreparedStatement("query2", 2)
+	suite.simulateExecutePreparedStatement(2)
+	suite.simulateClosePreparedStatement(1)
+	conn := suite.createOpenConnection(1)
+
+	_, err := conn.Exec("query1", []driver.Value{"value"})
+	suite.NoError(err)
+	_, err = conn.Exec("query2", []driver.Value{"value"})
+	suite.NoError(err)
+	suite.Equal(1, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestExecutionErrorClosesStatement() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(1), mockException)
+	suite.simulateClosePreparedStatement(1)
+	conn := suite.createOpenConnection(1)
+
+	_, err := conn.Exec("query", []driver.Value{"value"})
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Equal(0, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+var invalidStatementException = types.Exception{Text: "invalid statement handle", SQLCode: invalidStatementSQLCode}
+
+func (suite *StatementCacheTestSuite) TestInvalidCachedStatementIsPreparedAgain() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.simulateExecutePreparedStatement(1)
+	// The cached statement became invalid, e.g. because the table was altered
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(1), invalidStatementException)
+	suite.simulateClosePreparedStatement(1)
+	suite.simulateCreatePreparedStatement("query", 2)
+	suite.simulateExecutePreparedStatement(2)
+	conn := suite.createOpenConnection(1)
+
+	for i := 0; i < 2; i++ {
+		_, err := conn.Exec("query", []driver.Value{"value"})
+		suite.NoError(err)
+	}
+	suite.Equal(1, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestInvalidCachedStatementIsPreparedAgainOnlyOnce() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.simulateExecutePreparedStatement(1)
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(1), invalidStatementException)
+	suite.simulateClosePreparedStatement(1)
+	suite.simulateCreatePreparedStatement("query", 2)
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(2), invalidStatementException)
+	suite.simulateClosePreparedStatement(2)
+	conn := suite.createOpenConnection(1)
+
+	_, err := conn.Exec("query", []driver.Value{"value"})
+	suite.NoError(err)
+	_, err = conn.Exec("query", []driver.Value{"value"})
+	suite.EqualError(err, mockExceptionError(invalidStatementException))
+	suite.Equal(0, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestOtherExecutionErrorOfCachedStatementIsNotRetried() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.simulateExecutePreparedStatement(1)
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(1), mockException)
+	suite.simulateClosePreparedStatement(1)
+	conn := suite.createOpenConnection(1)
+
+	_, err := conn.Exec("query", []driver.Value{"value"})
+	suite.NoError(err)
+	_, err = conn.Exec("query", []driver.Value{"value"})
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Equal(0, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestInvalidCachedStatementIsNotRetriedInTransaction() {
+	suite.simulateCreatePreparedStatement("query", 1)
+	suite.simulateExecutePreparedStatement(1)
+	suite.websocketMock.SimulateErrorResponse(suite.executeCommand(1), invalidStatementException)
+	suite.simulateClosePreparedStatement(1)
+	conn := suite.createOpenConnection(1)
+
+	_, err := conn.Exec("query", []driver.Value{"value"})
+	suite.NoError(err)
+	conn.updateSessionState(&types.SessionAttributes{Autocommit: utils.BoolToPtr(false)})
+	_, err = conn.Exec("query", []driver.Value{"value"})
+	suite.EqualError(err, mockExceptionError(invalidStatementException))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) TestCloseClosesCachedStatements() {
+	suite.simulateClosePreparedStatement(1)
+	suite.websocketMock.SimulateOKResponse(types.Command{Command: "disconnect"}, nil)
+	suite.websocketMock.OnClose(nil)
+	conn := suite.createOpenConnection(1)
+	conn.getStatementCache().put("query", &types.CreatePreparedStatementResponse{StatementHandle: 1})
+
+	suite.NoError(conn.Close())
+	suite.Equal(0, conn.getStatementCache().len())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *StatementCacheTestSuite) simulateCreatePreparedStatement(query string, handle int) {
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    query,
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			StatementHandle: handle,
+			ParameterData:   types.ParameterData{NumColumns: 1, Columns: []types.SqlQueryColumn{{Name: "col", DataType: types.SqlQueryColumnType{Type: "type"}}}}})
+}
+
+func (suite *StatementCacheTestSuite) simulateExecutePreparedStatement(handle int) {
+	suite.websocketMock.SimulateSQLQueriesResponse(suite.executeCommand(handle),
+		types.SqlQueryResponseRowCount{ResultType: types.ResultTypeRowCount, RowCount: 1})
+}
+
+func (suite *StatementCacheTestSuite) executeCommand(handle int) types.ExecutePreparedStatementCommand {
+	return types.ExecutePreparedStatementCommand{Command: types.Command{Command: "executePreparedStatement"},
+		StatementHandle: handle, NumColumns: 1, NumRows: 1,
+		Columns: []types.SqlQueryColumn{{Name: "col", DataType: types.SqlQueryColumnType{Type: "type"}}},
+		Data:    [][]interface{}{{"value"}},
+	}
+}
+
+func (suite *StatementCacheTestSuite) simulateClosePreparedStatement(handle int) {
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: handle, Attributes: types.Attributes{}}, nil)
+}
+
+func (suite *StatementCacheTestSuite) createOpenConnection(cacheSize int) *Connection {
+	conn := &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345, User: "user", Password: "password", ApiVersion: 42, Autocommit: true, StatementCacheSize: cacheSize},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+	conn.initSessionState()
+	return conn
+}
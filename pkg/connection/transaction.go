@@ -6,10 +6,12 @@ import (
 
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
 type Transaction struct {
-	connection *Connection
+	connection        *Connection
+	restoreAttributes *types.Attributes // session attributes changed by BeginTx, restored when the transaction ends
 }
 
 func NewTransaction(connection *Connection) *Transaction {
@@ -17,19 +19,14 @@ func NewTransaction(connection *Connection) *Transaction {
 }
 
 func (t *Transaction) Commit() error {
-	if t.connection == nil {
-		return errors.ErrInvalidConn
-	}
-	if t.connection.IsClosed {
-		logger.ErrorLogger.Print(errors.ErrClosed)
-		return driver.ErrBadConn
-	}
-	_, err := t.connection.SimpleExec(context.Background(), "COMMIT")
-	t.connection = nil
-	return err
+	return t.finish("COMMIT")
 }
 
 func (t *Transaction) Rollback() error {
+	return t.finish("ROLLBACK")
+}
+
+func (t *Transaction) finish(sql string) error {
 	if t.connection == nil {
 		return errors.ErrInvalidConn
 	}
@@ -37,7 +34,13 @@ func (t *Transaction) Rollback() error {
 		logger.ErrorLogger.Print(errors.ErrClosed)
 		return driver.ErrBadConn
 	}
-	_, err := t.connection.SimpleExec(context.Background(), "ROLLBACK")
+	_, err := t.connection.SimpleExec(context.Background(), sql)
+	if t.restoreAttributes != nil {
+		restoreErr := t.connection.setAttributes(context.Background(), *t.restoreAttributes)
+		if err == nil {
+			err = restoreErr
+		}
+	}
 	t.connection = nil
 	return err
 }
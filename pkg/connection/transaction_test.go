@@ -1,27 +1,37 @@
 package connection
 
 import (
+	"context"
 	"database/sql/driver"
 	"testing"
 
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
 	"github.com/stretchr/testify/suite"
 )
 
 type TransactionTestSuite struct {
 	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
 }
 
 func TestTransactionSuite(t *testing.T) {
 	suite.Run(t, new(TransactionTestSuite))
 }
 
+func (suite *TransactionTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
 func (suite *TransactionTestSuite) TestCommitWithEmptyConnection() {
-	transaction := Transaction{nil}
+	transaction := Transaction{connection: nil}
 	suite.EqualError(transaction.Commit(), "E-EGOD-1: invalid connection")
 }
 
 func (suite *TransactionTestSuite) TestRollbackWithEmptyConnection() {
-	transaction := Transaction{nil}
+	transaction := Transaction{connection: nil}
 	suite.EqualError(transaction.Rollback(), "E-EGOD-1: invalid connection")
 }
 
@@ -36,3 +46,55 @@ func (suite *TransactionTestSuite) TestRollbackWithClosedConnection() {
 	transaction := Transaction{connection: &connection}
 	suite.EqualError(transaction.Rollback(), driver.ErrBadConn.Error())
 }
+
+func (suite *TransactionTestSuite) TestCommit() {
+	suite.simulateExecute("COMMIT")
+	transaction := NewTransaction(suite.createOpenConnection())
+	suite.NoError(transaction.Commit())
+	suite.Nil(transaction.connection)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *TransactionTestSuite) TestCommitRestoresAttributes() {
+	suite.simulateExecute("COMMIT")
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true)})
+	transaction := Transaction{connection: suite.createOpenConnection(), restoreAttributes: &types.Attributes{Autocommit: utils.BoolToPtr(true)}}
+	suite.NoError(transaction.Commit())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *TransactionTestSuite) TestRollbackRestoresAttributes() {
+	suite.simulateExecute("ROLLBACK")
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true)})
+	conn := suite.createOpenConnection()
+	transaction := Transaction{connection: conn, restoreAttributes: &types.Attributes{Autocommit: utils.BoolToPtr(true)}}
+	suite.NoError(transaction.Rollback())
+	suite.True(conn.getSessionState().autocommit)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *TransactionTestSuite) TestRollbackRestoresAttributesAfterFailure() {
+	suite.websocketMock.SimulateErrorResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "ROLLBACK"}, mockException)
+	suite.simulateSetAttributes(types.Attributes{Autocommit: utils.BoolToPtr(true)})
+	transaction := Transaction{connection: suite.createOpenConnection(), restoreAttributes: &types.Attributes{Autocommit: utils.BoolToPtr(true)}}
+	suite.EqualError(transaction.Rollback(), mockExceptionError(mockException))
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *TransactionTestSuite) simulateExecute(sql string) {
+	suite.websocketMock.SimulateSQLQueriesResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: sql},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount"})
+}
+
+func (suite *TransactionTestSuite) simulateSetAttributes(attributes types.Attributes) {
+	suite.websocketMock.SimulateOKResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"}, Attributes: attributes}, nil)
+}
+
+func (suite *TransactionTestSuite) createOpenConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
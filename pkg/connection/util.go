@@ -1,20 +1,66 @@
 package connection
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"strconv"
 
 	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
+// ToRow converts the response of a query to rows. A row count result, e.g. of a DML or DDL statement, has no columns and no rows.
 func ToRow(result *types.SqlQueriesResponse, con *Connection) (driver.Rows, error) {
 	resultSet := &types.SqlQueryResponseResultSet{}
-	err := json.Unmarshal(result.Results[0], resultSet)
+	err := unmarshalResponseData(result.Results[0], resultSet)
 	if err != nil {
 		return nil, err
 	}
 
-	return &QueryResults{data: &resultSet.ResultSet, con: con}, nil
+	return &QueryResults{data: &resultSet.ResultSet, con: con, nextResults: result.Results[1:]}, nil
+}
+
+// toBatchRows converts all results of a batch response to rows. Further results are available via [QueryResults.NextResultSet].
+// Row count results are converted to result sets, see toResultSetData.
+func toBatchRows(result *types.SqlQueriesResponse, con *Connection) (driver.Rows, error) {
+	data, err := toResultSetData(result.Results[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryResults{data: data, con: con, nextResults: result.Results[1:]}, nil
+}
+
+// RowsAffectedColumn is the name of the column of the result set that represents a row count result.
+const RowsAffectedColumn = "ROWS_AFFECTED"
+
+// toResultSetData converts a single result to result set data.
+// A row count result is converted to a result set with a single row containing the number of affected rows.
+func toResultSetData(result json.RawMessage) (*types.SqlQueryResponseResultSetData, error) {
+	resultSet := &types.SqlQueryResponseResultSet{}
+	err := unmarshalResponseData(result, resultSet)
+	if err != nil {
+		return nil, err
+	}
+	if resultSet.ResultType != types.ResultTypeRowCount {
+		return &resultSet.ResultSet, nil
+	}
+	rowCount := &types.SqlQueryResponseRowCount{}
+	err = json.Unmarshal(result, rowCount)
+	if err != nil {
+		return nil, err
+	}
+	precision, scale := int64(18), int64(0)
+	return &types.SqlQueryResponseResultSetData{
+		NumColumns:       1,
+		NumRows:          1,
+		NumRowsInMessage: 1,
+		Columns: []types.SqlQueryColumn{{
+			Name:     RowsAffectedColumn,
+			DataType: types.SqlQueryColumnType{Type: "DECIMAL", Precision: &precision, Scale: &scale},
+		}},
+		Data: [][]interface{}{{json.Number(strconv.Itoa(rowCount.RowCount))}},
+	}, nil
 }
 
 func ToResult(result *types.SqlQueriesResponse) (driver.Result, error) {
@@ -26,3 +72,11 @@ func ToResult(result *types.SqlQueriesResponse) (driver.Result, error) {
 
 	return &RowCount{affectedRows: int64(rowCountResult.RowCount)}, nil
 }
+
+// unmarshalResponseData decodes response data keeping numbers as json.Number,
+// so that result set values don't lose precision by converting them to float64.
+func unmarshalResponseData(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
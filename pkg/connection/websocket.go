@@ -42,6 +42,7 @@ func (c *Connection) Connect() error {
 		}
 		c.websocket, err = c.connectToHost(*url)
 		if err == nil {
+			c.host = host
 			return nil
 		}
 	}
@@ -67,22 +68,33 @@ func (c *Connection) connectToHost(url url.URL) (wsconn.WebsocketConnection, err
 }
 
 func (c *Connection) Send(ctx context.Context, request, response interface{}) error {
-	receiver, err := c.asyncSend(request)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	messageType, message, err := c.encodeRequest(request)
+	if err != nil {
+		return err
+	}
+	if c.websocket == nil {
+		return errors.NewWebsocketNotConnected(string(message))
+	}
+	dispatcher := c.getDispatcher()
+	pending, err := dispatcher.send(messageType, message)
 	if err != nil {
 		return err
 	}
-	channel := make(chan error, 1)
-	go func() { channel <- receiver(response) }()
 	select {
 	case <-ctx.Done():
 		logger.TraceLogger.Printf("Received context done signal. Context error: %v", ctx.Err())
-		_, err := c.asyncSend(&types.Command{Command: "abortQuery"})
+		// The response of the aborted request is read and discarded by the dispatcher.
+		err := c.abortQuery(dispatcher)
 		if err != nil {
 			logger.ErrorLogger.Printf("Could not abort query: %v", err)
 			return errors.NewErrCouldNotAbort(ctx.Err())
 		}
 		return ctx.Err()
-	case err := <-channel:
+	case received := <-pending.response:
+		err := c.handleResponse(dispatcher, received, response)
 		if err != nil {
 			logger.TraceLogger.Printf("Received error from channel: %v", err)
 		}
@@ -90,73 +102,86 @@ func (c *Connection) Send(ctx context.Context, request, response interface{}) er
 	}
 }
 
-func (c *Connection) asyncSend(request interface{}) (func(interface{}) error, error) {
+func (c *Connection) abortQuery(dispatcher *dispatcher) error {
+	messageType, message, err := c.encodeRequest(&types.Command{Command: "abortQuery"})
+	if err != nil {
+		return err
+	}
+	return dispatcher.sendWithoutResponse(messageType, message)
+}
+
+// getDispatcher returns the dispatcher for the current websocket, creating it if necessary.
+func (c *Connection) getDispatcher() *dispatcher {
+	c.dispatcherMutex.Lock()
+	defer c.dispatcherMutex.Unlock()
+	if c.dispatcher == nil || c.dispatcher.websocket != c.websocket {
+		c.dispatcher = newDispatcher(c.websocket)
+	}
+	return c.dispatcher
+}
+
+func (c *Connection) encodeRequest(request interface{}) (int, []byte, error) {
 	message, err := json.Marshal(request)
 	if err != nil {
 		logger.ErrorLogger.Print(errors.NewMarshallingError(request, err))
-		return nil, driver.ErrBadConn
+		return 0, nil, driver.ErrBadConn
 	}
 	logger.TraceLogger.Printf("Sending message: %s", message)
 
-	messageType := websocket.TextMessage
-	if c.Config.Compression {
-		var b bytes.Buffer
-		w := zlib.NewWriter(&b)
-		_, err = w.Write(message)
-		if err != nil {
-			return nil, err
-		}
-		w.Close()
-		message = b.Bytes()
-		messageType = websocket.BinaryMessage
+	if !c.Config.Compression {
+		return websocket.TextMessage, message, nil
 	}
-
-	if c.websocket == nil {
-		return nil, errors.NewWebsocketNotConnected(string(message))
-	}
-	err = c.websocket.WriteMessage(messageType, message)
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	_, err = w.Write(message)
 	if err != nil {
-		wrappedError := errors.NewRequestSendingError(err)
-		logger.ErrorLogger.Print(wrappedError)
-		return nil, wrappedError
+		return 0, nil, err
 	}
-
-	return c.callback(), nil
+	w.Close()
+	return websocket.BinaryMessage, b.Bytes(), nil
 }
 
-func (c *Connection) callback() func(response interface{}) error {
-	return func(response interface{}) error {
-		_, message, err := c.websocket.ReadMessage()
-		if err != nil {
-			wrappedError := errors.NewReceivingError(err)
-			logger.ErrorLogger.Print(wrappedError)
-			return wrappedError
-		}
+func (c *Connection) handleResponse(dispatcher *dispatcher, received receivedMessage, response interface{}) error {
+	if received.err != nil {
+		return received.err
+	}
 
-		result, err := c.parseResponse(message)
-		if err != nil {
-			return err
-		}
+	result, err := c.parseResponse(received.data)
+	if err != nil {
+		// We can't tell which request an undecodable message belongs to.
+		dispatcher.markBroken(err)
+		return err
+	}
+
+	if result.Attributes != nil {
+		c.updateSessionState(result.Attributes)
+	}
 
-		if result.Status != "ok" {
-			if result.Exception != nil {
-				return errors.NewSqlErr(result.Exception.SQLCode, result.Exception.Text)
-			} else {
-				return fmt.Errorf("result status is not 'ok': %q, expected exception in response %v", result.Status, result)
+	if result.Status != "ok" {
+		if result.Exception != nil {
+			if response != nil && len(result.ResponseData) > 0 {
+				// Keep partial results, e.g. of the statements of a batch executed before the failing one.
+				if err := unmarshalResponseData(result.ResponseData, response); err != nil {
+					logger.TraceLogger.Printf("Failed to parse response data of failed request: %v", err)
+				}
 			}
+			return errors.NewSqlErr(result.Exception.SQLCode, result.Exception.Text)
+		} else {
+			return fmt.Errorf("result status is not 'ok': %q, expected exception in response with data %q",
+				result.Status, string(result.ResponseData))
 		}
+	}
 
-		if response == nil {
-			// No response expected
-			return nil
-		}
-		logger.TraceLogger.Printf("Received response with status %q with %d bytes data", result.Status, len(result.ResponseData))
-		err = json.Unmarshal(result.ResponseData, response)
-		if err != nil {
-			return fmt.Errorf("failed to parse response data %q: %w", result.ResponseData, err)
-		}
+	if response == nil {
+		// No response expected
 		return nil
 	}
+	logger.TraceLogger.Printf("Received response with status %q with %d bytes data", result.Status, len(result.ResponseData))
+	err = unmarshalResponseData(result.ResponseData, response)
+	if err != nil {
+		return fmt.Errorf("failed to parse response data %q: %w", result.ResponseData, err)
+	}
+	return nil
 }
 
 func (c *Connection) parseResponse(message []byte) (*types.BaseResponse, error) {
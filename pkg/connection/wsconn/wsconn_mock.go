@@ -25,8 +25,12 @@ func CreateWebsocketConnectionMock() *WebsocketConnectionMock {
 	return mock
 }
 
-func (mock *WebsocketConnectionMock) SimulateSQLQueriesResponse(request interface{}, results interface{}) {
-	mock.SimulateResponse(request, baseOKResponse(types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{JsonMarshall(results)}}))
+func (mock *WebsocketConnectionMock) SimulateSQLQueriesResponse(request interface{}, results ...interface{}) {
+	rawResults := make([]json.RawMessage, 0, len(results))
+	for _, result := range results {
+		rawResults = append(rawResults, JsonMarshall(result))
+	}
+	mock.SimulateResponse(request, baseOKResponse(types.SqlQueriesResponse{NumResults: len(rawResults), Results: rawResults}))
 }
 
 func (mock *WebsocketConnectionMock) SimulateOKResponse(request interface{}, response interface{}) {
@@ -95,6 +99,12 @@ func (mock *WebsocketConnectionMock) OnReadTextMessage(data []byte, returnedErro
 	mock.On("ReadMessage").Return(websocket.TextMessage, data, returnedError).Once()
 }
 
+// OnReadTextMessageAfter simulates a response that is returned only after the given channel is closed.
+func (wsMock *WebsocketConnectionMock) OnReadTextMessageAfter(release <-chan struct{}, data []byte, returnedError error) {
+	LOG.Printf("Expect delayed ws.ReadMessage() -> return (%d, `%s`, %v)", websocket.TextMessage, string(data), returnedError)
+	wsMock.On("ReadMessage").Return(websocket.TextMessage, data, returnedError).Once().Run(func(mock.Arguments) { <-release })
+}
+
 func (mock *WebsocketConnectionMock) OnReadCompressedMessage(data []byte, returnedError error) {
 	LOG.Printf("Expect compressed ws.ReadMessage() -> return (%d, `%s`, %v)", websocket.BinaryMessage, string(data), returnedError)
 	mock.On("ReadMessage").Return(websocket.BinaryMessage, compress(data), returnedError).Once()
@@ -23,7 +23,10 @@ func ToInternalConfig(dsnConfig *DSNConfig) *config.Config {
 		FetchSize:                 dsnConfig.FetchSize,
 		QueryTimeout:              dsnConfig.QueryTimeout,
 		Compression:               *dsnConfig.Compression,
+		ImportCompression:         dsnConfig.ImportCompression,
 		ResultSetMaxRows:          dsnConfig.ResultSetMaxRows,
+		StatementCacheSize:        dsnConfig.StatementCacheSize,
+		ParseTime:                 dsnConfig.ParseTime,
 		Encryption:                *dsnConfig.Encryption,
 		ValidateServerCertificate: *dsnConfig.ValidateServerCertificate,
 		CertificateFingerprint:    dsnConfig.CertificateFingerprint,
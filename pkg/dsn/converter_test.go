@@ -53,6 +53,22 @@ func (suite *ConverterTestSuite) TestConvertQueryTimeout() {
 	suite.Equal(42, config.QueryTimeout)
 }
 
+func (suite *ConverterTestSuite) TestConvertImportCompression() {
+	config := suite.convert("exa:localhost:1234;compression=0;importcompression=1")
+	suite.False(config.Compression)
+	suite.True(config.ImportCompression)
+}
+
+func (suite *ConverterTestSuite) TestConvertParseTime() {
+	suite.False(suite.convert("exa:localhost:1234").ParseTime)
+	suite.True(suite.convert("exa:localhost:1234;parsetime=1").ParseTime)
+}
+
+func (suite *ConverterTestSuite) TestConvertStatementCacheSize() {
+	config := suite.convert("exa:localhost:1234;statementcachesize=16")
+	suite.Equal(16, config.StatementCacheSize)
+}
+
 func (suite *ConverterTestSuite) TestConvertUrlpath() {
 	config := suite.convert("exa:localhost:1234;urlpath=/v1/databases/db123/connect?ticket=123")
 	suite.Equal("/v1/databases/db123/connect?ticket=123", config.UrlPath)
@@ -18,6 +18,8 @@ type DSNConfig struct {
 	Autocommit                *bool             // If true, commit() will be executed automatically after each statement. If false, commit() and rollback() must be executed manually. (default: true)
 	Encryption                *bool             // Encrypt the database connection via TLS (default: true)
 	Compression               *bool             // If true, the WebSocket data frame payload data is compressed. If false, it is not compressed. (default: false)
+	ImportCompression         bool              // If true, local IMPORT statements send uncompressed files gzip compressed (default: false)
+	ParseTime                 bool              // If true, DATE and TIMESTAMP values are returned as time.Time instead of strings (default: false)
 	ClientName                string            // Client name reported to the database (default: "Go client")
 	ClientVersion             string            // Client version reported to the database (default: "")
 	FetchSize                 int               // Fetch size for results in KiB (default: 2000 KiB)
@@ -26,6 +28,7 @@ type DSNConfig struct {
 	CertificateFingerprint    string            // Expected SHA256 checksum of the server's TLS certificate in Hex format (default: "")
 	Schema                    string            // Name of the schema to open during connection (default: "")
 	ResultSetMaxRows          int               // Maximum number of result set rows returned (default: 0, means no limit)
+	StatementCacheSize        int               // Maximum number of prepared statements cached per connection for parameterized queries (default: 0, means no caching)
 	Params                    map[string]string // Connection parameters
 	AccessToken               string            // Access token (alternative to username/password)
 	RefreshToken              string            // Refresh token (alternative to username/password)
@@ -44,6 +47,19 @@ func (c *DSNConfigBuilder) Compression(enabled bool) *DSNConfigBuilder {
 	return c
 }
 
+// ImportCompression defines if local IMPORT statements send uncompressed files gzip compressed (default: false).
+// Compression reduces the network traffic at the cost of CPU time on client and database.
+func (c *DSNConfigBuilder) ImportCompression(enabled bool) *DSNConfigBuilder {
+	c.Config.ImportCompression = enabled
+	return c
+}
+
+// ParseTime defines if DATE and TIMESTAMP values are returned as time.Time instead of strings (default: false).
+func (c *DSNConfigBuilder) ParseTime(enabled bool) *DSNConfigBuilder {
+	c.Config.ParseTime = enabled
+	return c
+}
+
 // Encryption defines if the database connection should be encrypted via TLS (default: true).
 // Please note that starting with version 8, Exasol does not support unencrypted connections
 // and connections will fail with the following error:
@@ -121,6 +137,14 @@ func (c *DSNConfigBuilder) ResultSetMaxRows(maxRows int) *DSNConfigBuilder {
 	return c
 }
 
+// StatementCacheSize sets the maximum number of prepared statements cached per connection (default: 0, means no caching).
+// Parameterized queries executed with Query or Exec reuse cached statements with the same SQL text instead of
+// creating and closing a prepared statement for each call.
+func (c *DSNConfigBuilder) StatementCacheSize(size int) *DSNConfigBuilder {
+	c.Config.StatementCacheSize = size
+	return c
+}
+
 // Schema sets the name of the schema to open during connection (default: "").
 func (c *DSNConfigBuilder) Schema(schema string) *DSNConfigBuilder {
 	c.Config.Schema = schema
@@ -151,6 +175,9 @@ func (c *DSNConfig) ToDSN() string {
 	if c.Compression != nil {
 		sb.WriteString(fmt.Sprintf("compression=%d;", utils.BoolToInt(*c.Compression)))
 	}
+	if c.ImportCompression {
+		sb.WriteString("importcompression=1;")
+	}
 	if c.Encryption != nil {
 		sb.WriteString(fmt.Sprintf("encryption=%d;", utils.BoolToInt(*c.Encryption)))
 	}
@@ -175,6 +202,12 @@ func (c *DSNConfig) ToDSN() string {
 	if c.Schema != "" {
 		sb.WriteString(fmt.Sprintf("schema=%s;", escapeDsnParamValue(c.Schema)))
 	}
+	if c.ParseTime {
+		sb.WriteString("parsetime=1;")
+	}
+	if c.StatementCacheSize != 0 {
+		sb.WriteString(fmt.Sprintf("statementcachesize=%d;", c.StatementCacheSize))
+	}
 	if c.UrlPath != "" {
 		sb.WriteString(fmt.Sprintf("urlpath=%s;", escapeDsnParamValue(c.UrlPath)))
 	}
@@ -264,6 +297,8 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 			config.CertificateFingerprint = unescapeDsnParamValue(value)
 		case "compression":
 			config.Compression = utils.BoolToPtr(value == "1")
+		case "importcompression":
+			config.ImportCompression = value == "1"
 		case "clientname":
 			config.ClientName = unescapeDsnParamValue(value)
 		case "clientversion":
@@ -288,6 +323,14 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 				return nil, errors.NewInvalidConnectionStringInvalidIntParam("resultsetmaxrows", value)
 			}
 			config.ResultSetMaxRows = maxRowsValue
+		case "parsetime":
+			config.ParseTime = value == "1"
+		case "statementcachesize":
+			cacheSizeValue, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("statementcachesize", value)
+			}
+			config.StatementCacheSize = cacheSizeValue
 		case "urlpath":
 			config.UrlPath = unescapeDsnParamValue(value)
 		default:
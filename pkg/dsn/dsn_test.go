@@ -29,7 +29,10 @@ func (suite *DsnTestSuite) TestParseValidDsnWithoutParameters() {
 	suite.Equal(2000, dsn.FetchSize)
 	suite.Equal(0, dsn.QueryTimeout)
 	suite.Equal(false, *dsn.Compression)
+	suite.Equal(false, dsn.ImportCompression)
+	suite.Equal(false, dsn.ParseTime)
 	suite.Equal(0, dsn.ResultSetMaxRows)
+	suite.Equal(0, dsn.StatementCacheSize)
 	suite.Equal(true, *dsn.Encryption)
 	suite.Equal(true, *dsn.ValidateServerCertificate)
 	suite.Equal("", dsn.CertificateFingerprint)
@@ -47,7 +50,10 @@ func (suite *DsnTestSuite) TestParseValidDsnWithParameters() {
 			"clientversion=1.0.0;" +
 			"schema=MY_SCHEMA;" +
 			"compression=1;" +
+			"importcompression=1;" +
+			"parsetime=1;" +
 			"resultsetmaxrows=100;" +
+			"statementcachesize=50;" +
 			"certificatefingerprint=fingerprint;" +
 			"urlpath=/v1/databases/websocket?token=abc;" +
 			"mycustomparam=value")
@@ -63,7 +69,10 @@ func (suite *DsnTestSuite) TestParseValidDsnWithParameters() {
 	suite.Equal(1000, dsn.FetchSize)
 	suite.Equal(10, dsn.QueryTimeout)
 	suite.Equal(true, *dsn.Compression)
+	suite.Equal(true, dsn.ImportCompression)
+	suite.Equal(true, dsn.ParseTime)
 	suite.Equal(100, dsn.ResultSetMaxRows)
+	suite.Equal(50, dsn.StatementCacheSize)
 	suite.Equal(false, *dsn.Encryption)
 	suite.Equal("fingerprint", dsn.CertificateFingerprint)
 	suite.Equal("/v1/databases/websocket?token=abc", dsn.UrlPath)
@@ -152,6 +161,12 @@ func (suite *DsnTestSuite) TestInvalidResultsetmaxrows() {
 	suite.EqualError(err, "E-EGOD-25: invalid 'resultsetmaxrows' value 'size', numeric expected")
 }
 
+func (suite *DsnTestSuite) TestInvalidStatementcachesize() {
+	dsn, err := ParseDSN("exa:localhost:1234;statementcachesize=size")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-25: invalid 'statementcachesize' value 'size', numeric expected")
+}
+
 func (suite *DsnTestSuite) TestConfigParseDsnCustomValues() {
 	dsn, err := ParseDSN(
 		"exa:localhost:1234;user=sys;password=exasol;autocommit=0;encryption=0;compression=1;validateservercertificate=0;certificatefingerprint=fingerprint;fetchsize=13;querytimeout=42;clientname=clientName;clientversion=clientVersion")
@@ -178,6 +193,27 @@ func (suite *DsnTestSuite) TestToDsnWithUserPassword() {
 	suite.Equal(value, dsn.ToDSN())
 }
 
+func (suite *DsnTestSuite) TestToDsnWithStatementCacheSize() {
+	const value = "exa:localhost:1234;user=sys;password=exasol;autocommit=1;compression=0;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client;statementcachesize=16"
+	dsn, err := ParseDSN(value)
+	suite.NoError(err)
+	suite.Equal(value, dsn.ToDSN())
+}
+
+func (suite *DsnTestSuite) TestToDsnWithImportCompression() {
+	const value = "exa:localhost:1234;user=sys;password=exasol;autocommit=1;compression=0;importcompression=1;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client"
+	dsn, err := ParseDSN(value)
+	suite.NoError(err)
+	suite.Equal(value, dsn.ToDSN())
+}
+
+func (suite *DsnTestSuite) TestToDsnWithParseTime() {
+	const value = "exa:localhost:1234;user=sys;password=exasol;autocommit=1;compression=0;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client;parsetime=1"
+	dsn, err := ParseDSN(value)
+	suite.NoError(err)
+	suite.Equal(value, dsn.ToDSN())
+}
+
 func (suite *DsnTestSuite) TestToDsnWithAccessToken() {
 	const value = "exa:localhost:1234;accesstoken=token;autocommit=1;compression=0;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client"
 	dsn, err := ParseDSN(value)
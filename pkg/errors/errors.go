@@ -26,6 +26,16 @@ var (
 				Message("could not create proxy connection to import file"))
 	ErrInvalidImportQuery = NewDriverErr(exaerror.New("E-EGOD-27").
 				Message("could not parse import query"))
+	ErrMixedNamedAndPositionalParameters = NewDriverErr(exaerror.New("E-EGOD-35").
+						Message("named and positional parameters can't be mixed"))
+	ErrEmptyBatch = NewDriverErr(exaerror.New("E-EGOD-36").
+			Message("batch does not contain any statements"))
+	ErrMultipleExportFiles = NewDriverErr(exaerror.New("E-EGOD-39").
+				Message("export into multiple local files is not supported"))
+	ErrReadOnlyTransactionNotSupported = NewDriverErr(exaerror.New("E-EGOD-45").
+						Message("read-only transactions are not supported by Exasol"))
+	ErrNamedParametersNotEnabled = NewDriverErr(exaerror.New("E-EGOD-46").
+					Message("named parameters are not enabled for the statement, prepare it with a context created by connection.WithNamedParameters()"))
 )
 
 func NewErrCertificateFingerprintMismatch(actualFingerprint, expectedFingerprint string) DriverErr {
@@ -36,10 +46,12 @@ func NewErrCertificateFingerprintMismatch(actualFingerprint, expectedFingerprint
 }
 
 func NewSqlErr(sqlCode string, msg string) DriverErr {
-	return NewDriverErr(exaerror.New("E-EGOD-11").
+	err := NewDriverErr(exaerror.New("E-EGOD-11").
 		Message("execution failed with SQL error code {{sql code}} and message {{text}}").
 		Parameter("sql code", sqlCode).
 		Parameter("text", msg))
+	err.sqlCode = sqlCode
+	return err
 }
 
 func NewErrCouldNotAbort(rootCause error) DriverErr {
@@ -162,10 +174,87 @@ func NewInvalidArgType(value interface{}, expectedType string) DriverErr {
 		Parameter(("expected type"), expectedType))
 }
 
+func NewErrConnectionOutOfSync(cause error) DriverErr {
+	return NewDriverErrWithCause(exaerror.New("E-EGOD-31").
+		Message("requests and responses are out of sync, connection can't be used anymore: {{cause}}").
+		Parameter("cause", cause), driver.ErrBadConn)
+}
+
+func NewErrUnsupportedIsolationLevel(isolationLevel string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-32").
+		Message("isolation level {{isolation level}} is not supported, Exasol only supports 'Serializable'").
+		Parameter("isolation level", isolationLevel))
+}
+
+func NewErrUnknownNamedParameter(name string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-33").
+		Message("named parameter {{name}} is not used in the query").
+		Parameter("name", name))
+}
+
+func NewErrMissingNamedParameter(name string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-34").
+		Message("no value given for parameter {{name}}").
+		Parameter("name", name))
+}
+
+func NewCouldNotCreateFile(path string, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-40").
+		Message("could not create file {{path}}: {{error}}").
+		Parameter("path", path).
+		Parameter("error", err))
+}
+
+func NewProxyCertificateError(err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-41").
+		Message("could not create certificate for encrypted proxy connection: {{error}}").
+		Parameter("error", err))
+}
+
+func NewCouldNotDecompressFile(path string, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-42").
+		Message("could not decompress file {{path}}: {{error}}").
+		Parameter("path", path).
+		Parameter("error", err))
+}
+
+func NewInvalidInterval(value string, intervalType string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-43").
+		Message("could not parse {{value}} as {{interval type}}").
+		Parameter("value", value).
+		Parameter("interval type", intervalType))
+}
+
+func NewNullInterval(intervalType string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-47").
+		Message("cannot scan NULL into {{interval type}}, scan into a pointer instead").
+		Parameter("interval type", intervalType))
+}
+
+func NewInvalidGeometry(value string, reason string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-44").
+		Message("could not parse geometry {{value}}: {{reason}}").
+		Parameter("value", value).
+		Parameter("reason", reason))
+}
+
+func NewBatchError(index int, cause error) BatchError {
+	if index < 0 {
+		return BatchError{DriverErr: NewDriverErrWithCause(exaerror.New("E-EGOD-38").
+			Message("execution of batch failed: {{cause}}").
+			Parameter("cause", cause), cause), Index: index}
+	}
+	return BatchError{DriverErr: NewDriverErrWithCause(exaerror.New("E-EGOD-37").
+		Message("execution of batch failed at statement {{index}}: {{cause}}").
+		Parameter("index", index).
+		Parameter("cause", cause), cause), Index: index}
+}
+
 // DriverErr This type represents an error that can occur when working with a database connection.
 type DriverErr struct {
 	message string
 	cause   error
+	sqlCode string
 }
 
 // Error converts the error to a string.
@@ -173,6 +262,11 @@ func (e DriverErr) Error() string {
 	return e.message
 }
 
+// SQLCode returns the SQL error code reported by the database or an empty string if the error was not caused by SQL execution.
+func (e DriverErr) SQLCode() string {
+	return e.sqlCode
+}
+
 func (e DriverErr) Unwrap() error {
 	return e.cause
 }
@@ -180,3 +274,10 @@ func (e DriverErr) Unwrap() error {
 func (e DriverErr) Is(target error) bool {
 	return errors.Is(e.cause, target)
 }
+
+// BatchError is returned when a statement of a batch fails.
+type BatchError struct {
+	DriverErr
+	// Index is the index of the failing statement in the batch or -1 if the database did not report it.
+	Index int
+}
@@ -153,3 +153,90 @@ func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidPort() {
 func (suite *ErrorsTestSuite) TestNewInvalidArgType() {
 	suite.EqualError(NewInvalidArgType("arg", "expected Type"), "E-EGOD-30: cannot convert argument 'arg' of type 'string' to 'expected Type' type")
 }
+
+func (suite *ErrorsTestSuite) TestNewErrConnectionOutOfSync() {
+	suite.EqualError(NewErrConnectionOutOfSync(fmt.Errorf("error")), "E-EGOD-31: requests and responses are out of sync, connection can't be used anymore: 'error'")
+}
+
+func (suite *ErrorsTestSuite) TestNewErrConnectionOutOfSyncIsBadConnection() {
+	err := NewErrConnectionOutOfSync(fmt.Errorf("error"))
+	suite.True(errors.Is(err, driver.ErrBadConn))
+}
+
+func (suite *ErrorsTestSuite) TestNewErrUnsupportedIsolationLevel() {
+	suite.EqualError(NewErrUnsupportedIsolationLevel("Read Committed"), "E-EGOD-32: isolation level 'Read Committed' is not supported, Exasol only supports 'Serializable'")
+}
+
+func (suite *ErrorsTestSuite) TestErrReadOnlyTransactionNotSupported() {
+	suite.EqualError(ErrReadOnlyTransactionNotSupported, "E-EGOD-45: read-only transactions are not supported by Exasol")
+}
+
+func (suite *ErrorsTestSuite) TestErrNamedParametersNotEnabled() {
+	suite.EqualError(ErrNamedParametersNotEnabled, "E-EGOD-46: named parameters are not enabled for the statement, prepare it with a context created by connection.WithNamedParameters()")
+}
+
+func (suite *ErrorsTestSuite) TestNewErrUnknownNamedParameter() {
+	suite.EqualError(NewErrUnknownNamedParameter("id"), "E-EGOD-33: named parameter 'id' is not used in the query")
+}
+
+func (suite *ErrorsTestSuite) TestNewErrMissingNamedParameter() {
+	suite.EqualError(NewErrMissingNamedParameter("id"), "E-EGOD-34: no value given for parameter 'id'")
+}
+
+func (suite *ErrorsTestSuite) TestErrMixedNamedAndPositionalParameters() {
+	suite.EqualError(ErrMixedNamedAndPositionalParameters, "E-EGOD-35: named and positional parameters can't be mixed")
+}
+
+func (suite *ErrorsTestSuite) TestErrEmptyBatch() {
+	suite.EqualError(ErrEmptyBatch, "E-EGOD-36: batch does not contain any statements")
+}
+
+func (suite *ErrorsTestSuite) TestNewSqlErrSQLCode() {
+	suite.Equal("42000", NewSqlErr("42000", "text").SQLCode())
+}
+
+func (suite *ErrorsTestSuite) TestSQLCodeOfOtherErrors() {
+	suite.Equal("", ErrInvalidConn.SQLCode())
+}
+
+func (suite *ErrorsTestSuite) TestNewBatchError() {
+	cause := NewSqlErr("42000", "text")
+	err := NewBatchError(2, cause)
+	suite.EqualError(err, "E-EGOD-37: execution of batch failed at statement '2': 'E-EGOD-11: execution failed with SQL error code '42000' and message 'text''")
+	suite.Equal(2, err.Index)
+	suite.Equal(cause, errors.Unwrap(err))
+}
+
+func (suite *ErrorsTestSuite) TestNewBatchErrorWithUnknownIndex() {
+	err := NewBatchError(-1, fmt.Errorf("error"))
+	suite.EqualError(err, "E-EGOD-38: execution of batch failed: 'error'")
+	suite.Equal(-1, err.Index)
+}
+
+func (suite *ErrorsTestSuite) TestErrMultipleExportFiles() {
+	suite.EqualError(ErrMultipleExportFiles, "E-EGOD-39: export into multiple local files is not supported")
+}
+
+func (suite *ErrorsTestSuite) TestNewCouldNotCreateFile() {
+	suite.EqualError(NewCouldNotCreateFile("file.csv", fmt.Errorf("error")), "E-EGOD-40: could not create file 'file.csv': 'error'")
+}
+
+func (suite *ErrorsTestSuite) TestNewProxyCertificateError() {
+	suite.EqualError(NewProxyCertificateError(fmt.Errorf("error")), "E-EGOD-41: could not create certificate for encrypted proxy connection: 'error'")
+}
+
+func (suite *ErrorsTestSuite) TestNewCouldNotDecompressFile() {
+	suite.EqualError(NewCouldNotDecompressFile("file.csv.gz", fmt.Errorf("error")), "E-EGOD-42: could not decompress file 'file.csv.gz': 'error'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidInterval() {
+	suite.EqualError(NewInvalidInterval("invalid", "INTERVAL YEAR TO MONTH"), "E-EGOD-43: could not parse 'invalid' as 'INTERVAL YEAR TO MONTH'")
+}
+
+func (suite *ErrorsTestSuite) TestNewNullInterval() {
+	suite.EqualError(NewNullInterval("INTERVAL YEAR TO MONTH"), "E-EGOD-47: cannot scan NULL into 'INTERVAL YEAR TO MONTH', scan into a pointer instead")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidGeometry() {
+	suite.EqualError(NewInvalidGeometry("POINT (1)", "expected number"), "E-EGOD-44: could not parse geometry 'POINT (1)': 'expected number'")
+}
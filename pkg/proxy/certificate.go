@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// proxyCertificate is generated once and used for all encrypted proxy connections.
+var proxyCertificate struct {
+	once        sync.Once
+	certificate tls.Certificate
+	publicKey   string
+	err         error
+}
+
+// ephemeralCertificate returns a self-signed certificate and the SHA256 fingerprint of its public key
+// in the format of the PUBLIC KEY clause, e.g. "sha256//<base64>".
+func ephemeralCertificate() (tls.Certificate, string, error) {
+	proxyCertificate.once.Do(func() {
+		proxyCertificate.certificate, proxyCertificate.publicKey, proxyCertificate.err = generateCertificate()
+	})
+	return proxyCertificate.certificate, proxyCertificate.publicKey, proxyCertificate.err
+}
+
+func generateCertificate() (tls.Certificate, string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return tls.Certificate{}, "", errors.NewProxyCertificateError(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "exasol-driver-go"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, "", errors.NewProxyCertificateError(err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return tls.Certificate{}, "", errors.NewProxyCertificateError(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{certificate}, PrivateKey: key}, PublicKeyFingerprint(publicKey), nil
+}
+
+// PublicKeyFingerprint returns the fingerprint of a DER encoded public key in the format of the PUBLIC KEY clause.
+func PublicKeyFingerprint(publicKey []byte) string {
+	checksum := sha256.Sum256(publicKey)
+	return "sha256//" + base64.StdEncoding.EncodeToString(checksum[:])
+}
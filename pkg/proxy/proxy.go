@@ -3,13 +3,17 @@ package proxy
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"net/http/httputil"
 	"os"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/logger"
@@ -17,18 +21,25 @@ import (
 
 type Proxy struct {
 	isClosed   bool
-	connection io.ReadWriteCloser
+	connection net.Conn
 	Host       string
 	Port       int
+	// PublicKey is the fingerprint of the certificate's public key if the connection is encrypted, see [Proxy.StartTLS].
+	PublicKey string
+	// Compression makes the proxy send the data gzip compressed as file data.csv.gz.
+	Compression bool
 }
 
 var magicWords = []interface{}{uint32(0x02212102), uint32(1), uint32(1)}
 
+// dialTimeout limits the time for connecting to a host, so that unreachable hosts are skipped quickly.
+const dialTimeout = 10 * time.Second
+
 func NewProxy(hosts []string, port int) (*Proxy, error) {
 	var wrappedErr error
 	for _, host := range hosts {
 		uri := net.JoinHostPort(host, fmt.Sprintf("%d", port))
-		con, err := net.Dial("tcp", uri)
+		con, err := net.DialTimeout("tcp", uri, dialTimeout)
 		if err == nil {
 			p := &Proxy{
 				connection: con,
@@ -72,11 +83,85 @@ func (p *Proxy) StartProxy() error {
 	return nil
 }
 
+// StartTLS encrypts the connection after the proxy was started. The proxy acts as TLS server using an ephemeral
+// self-signed certificate. The database verifies the certificate with the fingerprint in [Proxy.PublicKey].
+func (p *Proxy) StartTLS() error {
+	certificate, publicKey, err := ephemeralCertificate()
+	if err != nil {
+		logger.ErrorLogger.Print(err)
+		return err
+	}
+	p.connection = tls.Server(p.connection, &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	})
+	p.PublicKey = publicKey
+	return nil
+}
+
 func (p *Proxy) Write(ctx context.Context, files []*os.File, rowSeparator string) error {
+	readers := make([]io.Reader, 0, len(files))
+	for _, file := range files {
+		readers = append(readers, file)
+	}
+	return p.WriteData(ctx, readers, rowSeparator)
+}
+
+// WriteData sends the data of all readers as a single CSV file.
+func (p *Proxy) WriteData(ctx context.Context, readers []io.Reader, rowSeparator string) error {
+	return p.writeChunked(p.Compression, func(chunkedWriter io.Writer) error {
+		for _, reader := range readers {
+			err := WriteRows(ctx, reader, rowSeparator, "", chunkedWriter)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// WriteBlocks sends all blocks received from the channel as a single CSV file until the channel is closed.
+// Each block must consist of complete rows.
+func (p *Proxy) WriteBlocks(ctx context.Context, blocks <-chan []byte) error {
+	return p.writeChunked(p.Compression, func(chunkedWriter io.Writer) error {
+		for block := range blocks {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			_, err := chunkedWriter.Write(block)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// WriteContent sends the content written by writeContent as a single CSV file.
+// The content is compressed if [Proxy.Compression] is enabled.
+func (p *Proxy) WriteContent(writeContent func(writer io.Writer) error) error {
+	return p.writeChunked(p.Compression, writeContent)
+}
+
+// WriteCompressed sends data that is already gzip compressed as is. [Proxy.Compression] must be enabled.
+func (p *Proxy) WriteCompressed(ctx context.Context, reader io.Reader) error {
+	return p.writeChunked(false, func(chunkedWriter io.Writer) error {
+		_, err := io.Copy(&contextWriter{ctx: ctx, writer: chunkedWriter}, reader)
+		return err
+	})
+}
+
+// writeChunked sends the response headers and the content written by writeContent using chunked transfer encoding.
+// If compress is true, the content is gzip compressed on the fly.
+func (p *Proxy) writeChunked(compress bool, writeContent func(writer io.Writer) error) error {
+	fileName := "data.csv"
+	if p.Compression {
+		fileName = "data.csv.gz"
+	}
 	err := p.sendHeaders([]string{
 		"HTTP/1.1 200 OK",
 		"Content-Type: application/octet-stream",
-		"Content-Disposition: attachment; filename=data.csv",
+		"Content-Disposition: attachment; filename=" + fileName,
 		"Transfer-Encoding: chunked",
 		"Connection: close",
 	})
@@ -84,56 +169,115 @@ func (p *Proxy) Write(ctx context.Context, files []*os.File, rowSeparator string
 		return err
 	}
 	chunkedWriter := httputil.NewChunkedWriter(p.connection)
-	for _, file := range files {
-		err = p.SendFile(ctx, file, rowSeparator, chunkedWriter)
-		if err != nil {
-			return err
+	if compress {
+		gzipWriter := gzip.NewWriter(chunkedWriter)
+		err = writeContent(gzipWriter)
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
 		}
+	} else {
+		err = writeContent(chunkedWriter)
+	}
+	if err != nil {
+		return err
 	}
 	_, err = p.connection.Write([]byte("0\r\n\r\n")) // A final zero chunk
 	return err
 }
 
-func (p *Proxy) SendFile(ctx context.Context, file *os.File, rowSeparator string, chunkedWriter io.WriteCloser) error {
-	reader := bufio.NewReader(file)
+func (p *Proxy) SendFile(ctx context.Context, file io.Reader, rowSeparator string, chunkedWriter io.WriteCloser) error {
+	return WriteRows(ctx, file, rowSeparator, "", chunkedWriter)
+}
 
+// WriteRows writes the content of the reader row by row, i.e. each call of the writer's Write method
+// receives a complete row. A missing row separator at the end of the data is added.
+// If reading fails, the error is returned and the incomplete row is not written.
+//
+// If columnDelimiter is not empty, a row separator between an opening and a closing column delimiter is part of
+// a quoted CSV field and does not end the row. An escaped column delimiter consists of two delimiters,
+// so it does not change whether the reader is inside of a quoted field.
+func WriteRows(ctx context.Context, file io.Reader, rowSeparator string, columnDelimiter string, writer io.Writer) error {
+	reader := bufio.NewReader(file)
+	delimiter := byte('\n')
+	// Handle files which end on CR
+	if rowSeparator == "\r" {
+		delimiter = '\r'
+	}
+	var row []byte
+	quoted := false
 	for {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
-		delimiter := '\n'
-		// Handle files which end on CR
-		if rowSeparator == "\r" {
-			delimiter = '\r'
+		line, err := reader.ReadBytes(delimiter)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("failed to read data: %w", err)
 		}
-		line, err := reader.ReadBytes(byte(delimiter))
-		if err != nil && len(line) == 0 {
-			break
+		row = append(row, line...)
+		if columnDelimiter != "" && bytes.Count(line, []byte(columnDelimiter))%2 == 1 {
+			quoted = !quoted
 		}
-
-		if err != nil && len(line) != 0 {
-			line = append(line, []byte(rowSeparator)...)
+		if err == nil && quoted {
+			continue
+		}
+		if err != nil && len(row) != 0 {
+			row = append(row, []byte(rowSeparator)...)
 		}
 
-		if len(line) == 0 {
+		if len(row) == 0 {
 			break
 		}
-		_, err = chunkedWriter.Write(line)
+		_, writeErr := writer.Write(row)
+		if writeErr != nil {
+			return writeErr
+		}
 		if err != nil {
-			return err
+			break
 		}
+		row = row[:0]
 	}
 	return nil
 }
 
+// Read receives a file uploaded by the database, e.g. for an EXPORT statement, and writes its content to the writer.
+func (p *Proxy) Read(ctx context.Context, writer io.Writer) error {
+	request, err := http.ReadRequest(bufio.NewReader(p.connection))
+	if err != nil {
+		return fmt.Errorf("unable to read request from proxy: %w", err)
+	}
+	defer request.Body.Close()
+	_, err = io.Copy(&contextWriter{ctx: ctx, writer: writer}, request.Body)
+	if err != nil {
+		return err
+	}
+	return p.sendHeaders([]string{
+		"HTTP/1.1 200 OK",
+		"Content-Length: 0",
+		"Connection: close",
+	})
+}
+
+// contextWriter stops writing when the context is done.
+type contextWriter struct {
+	ctx    context.Context
+	writer io.Writer
+}
+
+func (w *contextWriter) Write(data []byte) (int, error) {
+	if w.ctx.Err() != nil {
+		return 0, w.ctx.Err()
+	}
+	return w.writer.Write(data)
+}
+
 func (p *Proxy) sendHeaders(headers []string) error {
 	headers = append(headers, "")
 	for _, header := range headers {
 		header += "\r\n"
 		_, err := p.connection.Write([]byte(header))
 		if err != nil {
-			return fmt.Errorf("unable to send header <%s>to proxy: %s", header, err)
+			return fmt.Errorf("unable to send header <%s>to proxy: %w", header, err)
 		}
 	}
 	return nil
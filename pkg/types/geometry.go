@@ -0,0 +1,341 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Geometry types supported by Exasol.
+const (
+	GeometryPoint              = "POINT"
+	GeometryLineString         = "LINESTRING"
+	GeometryLinearRing         = "LINEARRING"
+	GeometryPolygon            = "POLYGON"
+	GeometryMultiPoint         = "MULTIPOINT"
+	GeometryMultiLineString    = "MULTILINESTRING"
+	GeometryMultiPolygon       = "MULTIPOLYGON"
+	GeometryCollection         = "GEOMETRYCOLLECTION"
+	geometryEmpty              = "EMPTY"
+	geometryColumnType         = "GEOMETRY"
+	geometryUnexpectedEndError = "unexpected end"
+)
+
+// Point is a two-dimensional coordinate of a geometry.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Geometry is a value of a GEOMETRY column.
+//
+// Points contains the coordinates of a POINT, LINESTRING or LINEARRING. Parts contains the rings of a POLYGON
+// (as LINEARRING), the elements of a MULTIPOINT, MULTILINESTRING or MULTIPOLYGON and the geometries of a
+// GEOMETRYCOLLECTION. An empty geometry like "POINT EMPTY" has neither points nor parts.
+// The SRID is a property of the column, see connection.QueryResults.ColumnDataType.
+type Geometry struct {
+	Type   string
+	Points []Point
+	Parts  []Geometry
+}
+
+// IsEmpty returns true if the geometry has no points, e.g. "POINT EMPTY".
+func (g Geometry) IsEmpty() bool {
+	return len(g.Points) == 0 && len(g.Parts) == 0
+}
+
+// String returns the geometry in well-known text (WKT) format, e.g. "POINT (1 2)".
+func (g Geometry) String() string {
+	var builder strings.Builder
+	g.writeText(&builder)
+	return builder.String()
+}
+
+func (g Geometry) writeText(builder *strings.Builder) {
+	builder.WriteString(g.Type)
+	builder.WriteString(" ")
+	g.writeBody(builder)
+}
+
+func (g Geometry) writeBody(builder *strings.Builder) {
+	if g.IsEmpty() {
+		builder.WriteString(geometryEmpty)
+		return
+	}
+	builder.WriteString("(")
+	if len(g.Parts) == 0 {
+		for i, point := range g.Points {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(formatCoordinate(point.X))
+			builder.WriteString(" ")
+			builder.WriteString(formatCoordinate(point.Y))
+		}
+	}
+	for i, part := range g.Parts {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		if g.Type == GeometryCollection {
+			part.writeText(builder)
+		} else {
+			part.writeBody(builder)
+		}
+	}
+	builder.WriteString(")")
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Value implements the [driver.Valuer] interface and returns the geometry as WKT.
+// A zero Geometry, e.g. after scanning a NULL value, is converted to NULL.
+func (g Geometry) Value() (driver.Value, error) {
+	if g.Type == "" {
+		return nil, nil
+	}
+	return g.String(), nil
+}
+
+// Scan implements the [sql.Scanner] interface. It parses the WKT strings returned by the driver.
+// A NULL value results in a zero Geometry.
+func (g *Geometry) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return g.parse(src)
+	case []byte:
+		return g.parse(string(src))
+	case nil:
+		*g = Geometry{}
+		return nil
+	default:
+		return errors.NewInvalidArgType(src, geometryColumnType)
+	}
+}
+
+func (g *Geometry) parse(value string) error {
+	geometry, err := ParseGeometry(value)
+	if err != nil {
+		return err
+	}
+	*g = geometry
+	return nil
+}
+
+// ParseGeometry parses a geometry in well-known text (WKT) format like "POLYGON ((0 0, 1 0, 1 1, 0 0))".
+func ParseGeometry(wkt string) (Geometry, error) {
+	parser := &wktParser{text: wkt, tokens: tokenizeWkt(wkt)}
+	geometry, err := parser.geometry()
+	if err != nil {
+		return Geometry{}, err
+	}
+	if token, ok := parser.peek(); ok {
+		return Geometry{}, parser.error(fmt.Sprintf("unexpected %q", token))
+	}
+	return geometry, nil
+}
+
+type wktParser struct {
+	text   string
+	tokens []string
+	pos    int
+}
+
+func (p *wktParser) geometry() (Geometry, error) {
+	geometryType, err := p.next()
+	if err != nil {
+		return Geometry{}, err
+	}
+	geometry := Geometry{Type: strings.ToUpper(geometryType)}
+	if p.accept(geometryEmpty) {
+		return geometry, nil
+	}
+	switch geometry.Type {
+	case GeometryPoint:
+		geometry.Points, err = p.points(1)
+	case GeometryLineString, GeometryLinearRing:
+		geometry.Points, err = p.points(0)
+	case GeometryPolygon:
+		geometry.Parts, err = p.parts(GeometryLinearRing)
+	case GeometryMultiPoint:
+		geometry.Parts, err = p.multiPoint()
+	case GeometryMultiLineString:
+		geometry.Parts, err = p.parts(GeometryLineString)
+	case GeometryMultiPolygon:
+		geometry.Parts, err = p.parts(GeometryPolygon)
+	case GeometryCollection:
+		geometry.Parts, err = listOf(p, p.geometry)
+	default:
+		return Geometry{}, p.error(fmt.Sprintf("unknown geometry type %q", geometryType))
+	}
+	if err != nil {
+		return Geometry{}, err
+	}
+	return geometry, nil
+}
+
+// points parses a list of coordinates like "(1 2, 3 4)". A count greater than 0 requires exactly that many points.
+func (p *wktParser) points(count int) ([]Point, error) {
+	points, err := listOf(p, p.point)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 && len(points) != count {
+		return nil, p.error(fmt.Sprintf("expected %d point(s) but got %d", count, len(points)))
+	}
+	return points, nil
+}
+
+func (p *wktParser) point() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	token, err := p.next()
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, p.error(fmt.Sprintf("expected number but got %q", token))
+	}
+	return value, nil
+}
+
+// parts parses a list of untagged geometries of the given type, e.g. the rings of a polygon.
+func (p *wktParser) parts(partType string) ([]Geometry, error) {
+	return listOf(p, func() (Geometry, error) {
+		return p.untagged(partType)
+	})
+}
+
+func (p *wktParser) untagged(geometryType string) (Geometry, error) {
+	geometry := Geometry{Type: geometryType}
+	if p.accept(geometryEmpty) {
+		return geometry, nil
+	}
+	var err error
+	switch geometryType {
+	case GeometryPolygon:
+		geometry.Parts, err = p.parts(GeometryLinearRing)
+	default:
+		geometry.Points, err = p.points(0)
+	}
+	return geometry, err
+}
+
+// multiPoint parses the points of a MULTIPOINT with or without parentheses around each point,
+// e.g. "((1 2), (3 4))" or "(1 2, 3 4)".
+func (p *wktParser) multiPoint() ([]Geometry, error) {
+	return listOf(p, func() (Geometry, error) {
+		if token, ok := p.peek(); ok && token != "(" && !strings.EqualFold(token, geometryEmpty) {
+			point, err := p.point()
+			return Geometry{Type: GeometryPoint, Points: []Point{point}}, err
+		}
+		geometry := Geometry{Type: GeometryPoint}
+		if p.accept(geometryEmpty) {
+			return geometry, nil
+		}
+		var err error
+		geometry.Points, err = p.points(1)
+		return geometry, err
+	})
+}
+
+// listOf parses a comma separated list of elements in parentheses.
+func listOf[T any](p *wktParser, element func() (T, error)) ([]T, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var elements []T
+	for {
+		value, err := element()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, value)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+func (p *wktParser) peek() (string, bool) {
+	if p.pos >= len(p.tokens) {
+		return "", false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *wktParser) next() (string, error) {
+	token, ok := p.peek()
+	if !ok {
+		return "", p.error(geometryUnexpectedEndError)
+	}
+	p.pos++
+	return token, nil
+}
+
+func (p *wktParser) accept(expected string) bool {
+	if token, ok := p.peek(); ok && strings.EqualFold(token, expected) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(expected string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if token != expected {
+		return p.error(fmt.Sprintf("expected %q but got %q", expected, token))
+	}
+	return nil
+}
+
+func (p *wktParser) error(reason string) error {
+	return errors.NewInvalidGeometry(p.text, reason)
+}
+
+// tokenizeWkt splits WKT into parentheses, commas and words or numbers.
+func tokenizeWkt(wkt string) []string {
+	var tokens []string
+	start := -1
+	for i, r := range wkt {
+		if unicode.IsSpace(r) || r == '(' || r == ')' || r == ',' {
+			if start >= 0 {
+				tokens = append(tokens, wkt[start:i])
+				start = -1
+			}
+			if !unicode.IsSpace(r) {
+				tokens = append(tokens, string(r))
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, wkt[start:])
+	}
+	return tokens
+}
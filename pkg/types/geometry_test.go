@@ -0,0 +1,129 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GeometryTestSuite struct {
+	suite.Suite
+}
+
+func TestGeometryTestSuite(t *testing.T) {
+	suite.Run(t, new(GeometryTestSuite))
+}
+
+var _ sql.Scanner = (*Geometry)(nil)
+var _ driver.Valuer = Geometry{}
+
+func ring(points ...Point) Geometry {
+	return Geometry{Type: GeometryLinearRing, Points: points}
+}
+
+func (suite *GeometryTestSuite) TestParseGeometry() {
+	square := ring(Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{0, 0})
+	hole := ring(Point{0.2, 0.2}, Point{0.5, 0.2}, Point{0.2, 0.5}, Point{0.2, 0.2})
+	for _, testCase := range []struct {
+		wkt      string
+		expected Geometry
+	}{
+		{"POINT (1 2)", Geometry{Type: GeometryPoint, Points: []Point{{1, 2}}}},
+		{"point(-1.5 2e3)", Geometry{Type: GeometryPoint, Points: []Point{{-1.5, 2000}}}},
+		{"POINT EMPTY", Geometry{Type: GeometryPoint}},
+		{"LINESTRING (1 2, 3 4)", Geometry{Type: GeometryLineString, Points: []Point{{1, 2}, {3, 4}}}},
+		{"LINEARRING (0 0, 1 0, 1 1, 0 0)", square},
+		{"POLYGON ((0 0, 1 0, 1 1, 0 0))", Geometry{Type: GeometryPolygon, Parts: []Geometry{square}}},
+		{"POLYGON ((0 0, 1 0, 1 1, 0 0), (0.2 0.2, 0.5 0.2, 0.2 0.5, 0.2 0.2))", Geometry{Type: GeometryPolygon, Parts: []Geometry{square, hole}}},
+		{"MULTIPOINT ((1 2), (3 4))", Geometry{Type: GeometryMultiPoint, Parts: []Geometry{
+			{Type: GeometryPoint, Points: []Point{{1, 2}}}, {Type: GeometryPoint, Points: []Point{{3, 4}}}}}},
+		{"MULTIPOINT (1 2, 3 4)", Geometry{Type: GeometryMultiPoint, Parts: []Geometry{
+			{Type: GeometryPoint, Points: []Point{{1, 2}}}, {Type: GeometryPoint, Points: []Point{{3, 4}}}}}},
+		{"MULTILINESTRING ((1 2, 3 4), EMPTY)", Geometry{Type: GeometryMultiLineString, Parts: []Geometry{
+			{Type: GeometryLineString, Points: []Point{{1, 2}, {3, 4}}}, {Type: GeometryLineString}}}},
+		{"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)))", Geometry{Type: GeometryMultiPolygon, Parts: []Geometry{
+			{Type: GeometryPolygon, Parts: []Geometry{square}}}}},
+		{"GEOMETRYCOLLECTION (POINT (1 2), LINESTRING (1 2, 3 4))", Geometry{Type: GeometryCollection, Parts: []Geometry{
+			{Type: GeometryPoint, Points: []Point{{1, 2}}}, {Type: GeometryLineString, Points: []Point{{1, 2}, {3, 4}}}}}},
+		{"GEOMETRYCOLLECTION EMPTY", Geometry{Type: GeometryCollection}},
+	} {
+		suite.Run(testCase.wkt, func() {
+			geometry, err := ParseGeometry(testCase.wkt)
+			suite.NoError(err)
+			suite.Equal(testCase.expected, geometry)
+		})
+	}
+}
+
+func (suite *GeometryTestSuite) TestParseGeometryFails() {
+	for _, testCase := range []struct {
+		wkt    string
+		reason string
+	}{
+		{"", "unexpected end"},
+		{"CIRCLE (1 2)", `unknown geometry type "CIRCLE"`},
+		{"POINT (1)", `expected number but got ")"`},
+		{"POINT (1 2, 3 4)", "expected 1 point(s) but got 2"},
+		{"POINT (1 2 3)", `expected ")" but got "3"`},
+		{"POINT 1 2", `expected "(" but got "1"`},
+		{"POINT (1 2", "unexpected end"},
+		{"POINT (1 2))", `unexpected ")"`},
+		{"POLYGON (0 0, 1 1)", `expected "(" but got "0"`},
+	} {
+		suite.Run(testCase.wkt, func() {
+			_, err := ParseGeometry(testCase.wkt)
+			suite.EqualError(err, "E-EGOD-44: could not parse geometry '"+testCase.wkt+"': '"+testCase.reason+"'")
+		})
+	}
+}
+
+func (suite *GeometryTestSuite) TestString() {
+	for _, wkt := range []string{
+		"POINT (1 2.5)",
+		"POINT EMPTY",
+		"LINESTRING (1 2, 3 4)",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0), (0.2 0.2, 0.5 0.2, 0.2 0.5, 0.2 0.2))",
+		"MULTIPOINT ((1 2), (3 4))",
+		"MULTILINESTRING ((1 2, 3 4), EMPTY)",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+		"GEOMETRYCOLLECTION (POINT (1 2), POLYGON ((0 0, 1 0, 1 1, 0 0)), GEOMETRYCOLLECTION EMPTY)",
+	} {
+		suite.Run(wkt, func() {
+			geometry, err := ParseGeometry(wkt)
+			suite.Require().NoError(err)
+			suite.Equal(wkt, geometry.String())
+		})
+	}
+}
+
+func (suite *GeometryTestSuite) TestStringFormatsLargeCoordinatesWithoutExponent() {
+	suite.Equal("POINT (100000000000000000000 0.000001)", Geometry{Type: GeometryPoint, Points: []Point{{1e20, 1e-6}}}.String())
+}
+
+func (suite *GeometryTestSuite) TestValue() {
+	value, err := Geometry{Type: GeometryPoint, Points: []Point{{1, 2}}}.Value()
+	suite.NoError(err)
+	suite.Equal("POINT (1 2)", value)
+}
+
+func (suite *GeometryTestSuite) TestValueOfZeroGeometryIsNull() {
+	var geometry Geometry
+	suite.NoError(geometry.Scan(nil))
+	value, err := geometry.Value()
+	suite.NoError(err)
+	suite.Nil(value)
+}
+
+func (suite *GeometryTestSuite) TestScan() {
+	var geometry Geometry
+	for _, src := range []any{"POINT (1 2)", []byte("POINT (1 2)")} {
+		suite.NoError(geometry.Scan(src))
+		suite.Equal(Geometry{Type: GeometryPoint, Points: []Point{{1, 2}}}, geometry)
+	}
+	suite.NoError(geometry.Scan(nil))
+	suite.Equal(Geometry{}, geometry)
+	suite.EqualError(geometry.Scan(1), "E-EGOD-30: cannot convert argument '1' of type 'int' to 'GEOMETRY' type")
+	suite.EqualError(geometry.Scan("POINT"), "E-EGOD-44: could not parse geometry 'POINT': 'unexpected end'")
+}
@@ -0,0 +1,223 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+const (
+	IntervalDayToSecond = "INTERVAL DAY TO SECOND"
+	IntervalYearToMonth = "INTERVAL YEAR TO MONTH"
+)
+
+// YearMonthInterval is a value of an INTERVAL YEAR TO MONTH column. For negative intervals
+// both Years and Months are negative or zero.
+type YearMonthInterval struct {
+	Years  int
+	Months int
+}
+
+// NewYearMonthInterval creates an interval for the given total number of months.
+func NewYearMonthInterval(months int) YearMonthInterval {
+	return YearMonthInterval{Years: months / 12, Months: months % 12}
+}
+
+// TotalMonths returns the length of the interval in months.
+func (i YearMonthInterval) TotalMonths() int {
+	return i.Years*12 + i.Months
+}
+
+// String formats the interval like Exasol, e.g. "+05-03" or "-01-06".
+func (i YearMonthInterval) String() string {
+	months := i.TotalMonths()
+	sign := "+"
+	if months < 0 {
+		sign = "-"
+		months = -months
+	}
+	return fmt.Sprintf("%s%02d-%02d", sign, months/12, months%12)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (i YearMonthInterval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// Scan implements the [sql.Scanner] interface. It returns an error for NULL values like scanning NULL into an int.
+// Scan into a *YearMonthInterval to handle NULL values, it is set to nil for NULL.
+func (i *YearMonthInterval) Scan(src any) error {
+	switch src := src.(type) {
+	case YearMonthInterval:
+		*i = src
+		return nil
+	case string:
+		return i.parse(src)
+	case []byte:
+		return i.parse(string(src))
+	case nil:
+		return errors.NewNullInterval(IntervalYearToMonth)
+	default:
+		return errors.NewInvalidInterval(fmt.Sprint(src), IntervalYearToMonth)
+	}
+}
+
+func (i *YearMonthInterval) parse(value string) error {
+	parsed, err := ParseYearMonthInterval(value)
+	if err != nil {
+		return err
+	}
+	*i = parsed
+	return nil
+}
+
+// ParseYearMonthInterval parses an INTERVAL YEAR TO MONTH value like "+05-03", "5-3" or "-1-6".
+func ParseYearMonthInterval(value string) (YearMonthInterval, error) {
+	negative, unsigned := cutSign(strings.TrimSpace(value))
+	yearsText, monthsText, found := strings.Cut(unsigned, "-")
+	years, yearsErr := parseUnsigned(yearsText)
+	months, monthsErr := parseUnsigned(monthsText)
+	if !found || yearsErr != nil || monthsErr != nil || months >= 12 {
+		return YearMonthInterval{}, errors.NewInvalidInterval(value, IntervalYearToMonth)
+	}
+	if negative {
+		return YearMonthInterval{Years: -years, Months: -months}, nil
+	}
+	return YearMonthInterval{Years: years, Months: months}, nil
+}
+
+// DayToSecondInterval is a value of an INTERVAL DAY TO SECOND column. It can be converted to [time.Duration].
+type DayToSecondInterval time.Duration
+
+// Duration returns the interval as [time.Duration].
+func (i DayToSecondInterval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+// String formats the interval like Exasol, e.g. "+02 12:50:10.123".
+func (i DayToSecondInterval) String() string {
+	return FormatDayToSecondInterval(time.Duration(i))
+}
+
+// Value implements the [driver.Valuer] interface.
+func (i DayToSecondInterval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// Scan implements the [sql.Scanner] interface. It returns an error for NULL values like scanning NULL into a
+// time.Duration. Scan into a *DayToSecondInterval to handle NULL values, it is set to nil for NULL.
+func (i *DayToSecondInterval) Scan(src any) error {
+	switch src := src.(type) {
+	case time.Duration:
+		*i = DayToSecondInterval(src)
+		return nil
+	case string:
+		return i.parse(src)
+	case []byte:
+		return i.parse(string(src))
+	case nil:
+		return errors.NewNullInterval(IntervalDayToSecond)
+	default:
+		return errors.NewInvalidInterval(fmt.Sprint(src), IntervalDayToSecond)
+	}
+}
+
+func (i *DayToSecondInterval) parse(value string) error {
+	duration, err := ParseDayToSecondInterval(value)
+	if err != nil {
+		return err
+	}
+	*i = DayToSecondInterval(duration)
+	return nil
+}
+
+// FormatDayToSecondInterval formats a duration as INTERVAL DAY TO SECOND value, e.g. "+02 12:50:10.123".
+// The fraction has at most nine digits and is omitted for whole seconds.
+func FormatDayToSecondInterval(duration time.Duration) string {
+	sign := "+"
+	// Use uint64 to avoid an overflow for math.MinInt64
+	nanos := uint64(duration)
+	if duration < 0 {
+		sign = "-"
+		nanos = -nanos
+	}
+	seconds := nanos / uint64(time.Second)
+	fraction := nanos % uint64(time.Second)
+	result := fmt.Sprintf("%s%02d %02d:%02d:%02d", sign, seconds/86400, seconds/3600%24, seconds/60%60, seconds%60)
+	if fraction > 0 {
+		result += strings.TrimRight(fmt.Sprintf(".%09d", fraction), "0")
+	}
+	return result
+}
+
+// ParseDayToSecondInterval parses an INTERVAL DAY TO SECOND value like "+02 12:50:10.123" or "-1 00:00:01".
+func ParseDayToSecondInterval(value string) (time.Duration, error) {
+	invalid := errors.NewInvalidInterval(value, IntervalDayToSecond)
+	negative, unsigned := cutSign(strings.TrimSpace(value))
+	daysText, timeText, found := strings.Cut(unsigned, " ")
+	if !found {
+		return 0, invalid
+	}
+	clock := strings.Split(timeText, ":")
+	if len(clock) != 3 {
+		return 0, invalid
+	}
+	secondsText, fractionText, hasFraction := strings.Cut(clock[2], ".")
+	days, daysErr := parseUnsigned(daysText)
+	hours, hoursErr := parseUnsigned(clock[0])
+	minutes, minutesErr := parseUnsigned(clock[1])
+	seconds, secondsErr := parseUnsigned(secondsText)
+	if daysErr != nil || hoursErr != nil || minutesErr != nil || secondsErr != nil || hours >= 24 || minutes >= 60 || seconds >= 60 {
+		return 0, invalid
+	}
+	var nanos int
+	if hasFraction {
+		if len(fractionText) > 9 {
+			return 0, invalid
+		}
+		fraction, err := parseUnsigned(fractionText)
+		if err != nil {
+			return 0, invalid
+		}
+		nanos = fraction * pow10(9-len(fractionText))
+	}
+	const maxDays = int(time.Duration(1<<63-1) / (24 * time.Hour))
+	if days > maxDays {
+		return 0, invalid
+	}
+	duration := time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second + time.Duration(nanos)
+	if duration < 0 {
+		return 0, invalid
+	}
+	if negative {
+		return -duration, nil
+	}
+	return duration, nil
+}
+
+func cutSign(value string) (negative bool, unsigned string) {
+	if strings.HasPrefix(value, "-") {
+		return true, value[1:]
+	}
+	return false, strings.TrimPrefix(value, "+")
+}
+
+func parseUnsigned(value string) (int, error) {
+	if value == "" || strings.ContainsAny(value, "+-") {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.Atoi(value)
+}
+
+func pow10(exponent int) int {
+	result := 1
+	for ; exponent > 0; exponent-- {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IntervalTestSuite struct {
+	suite.Suite
+}
+
+func TestIntervalTestSuite(t *testing.T) {
+	suite.Run(t, new(IntervalTestSuite))
+}
+
+var _ sql.Scanner = (*YearMonthInterval)(nil)
+var _ driver.Valuer = YearMonthInterval{}
+var _ sql.Scanner = (*DayToSecondInterval)(nil)
+var _ driver.Valuer = DayToSecondInterval(0)
+
+func (suite *IntervalTestSuite) TestParseYearMonthInterval() {
+	for _, testCase := range []struct {
+		value    string
+		expected YearMonthInterval
+	}{
+		{"+05-03", YearMonthInterval{Years: 5, Months: 3}},
+		{"5-3", YearMonthInterval{Years: 5, Months: 3}},
+		{"-01-06", YearMonthInterval{Years: -1, Months: -6}},
+		{"-00-06", YearMonthInterval{Years: 0, Months: -6}},
+		{"+999999999-11", YearMonthInterval{Years: 999999999, Months: 11}},
+		{" +00-00 ", YearMonthInterval{}},
+	} {
+		suite.Run(testCase.value, func() {
+			interval, err := ParseYearMonthInterval(testCase.value)
+			suite.NoError(err)
+			suite.Equal(testCase.expected, interval)
+		})
+	}
+}
+
+func (suite *IntervalTestSuite) TestParseYearMonthIntervalFails() {
+	for _, value := range []string{"", "5", "5-", "-5", "5-12", "a-1", "1--1", "+-1-1", "1-1-1"} {
+		suite.Run(value, func() {
+			_, err := ParseYearMonthInterval(value)
+			suite.EqualError(err, fmt.Sprintf("E-EGOD-43: could not parse '%s' as 'INTERVAL YEAR TO MONTH'", value))
+		})
+	}
+}
+
+func (suite *IntervalTestSuite) TestYearMonthIntervalString() {
+	suite.Equal("+05-03", YearMonthInterval{Years: 5, Months: 3}.String())
+	suite.Equal("-01-06", YearMonthInterval{Years: -1, Months: -6}.String())
+	suite.Equal("+00-11", YearMonthInterval{Years: 1, Months: -1}.String())
+	suite.Equal("+00-00", YearMonthInterval{}.String())
+}
+
+func (suite *IntervalTestSuite) TestNewYearMonthInterval() {
+	suite.Equal(YearMonthInterval{Years: 1, Months: 6}, NewYearMonthInterval(18))
+	suite.Equal(YearMonthInterval{Years: -1, Months: -6}, NewYearMonthInterval(-18))
+	suite.Equal(-18, NewYearMonthInterval(-18).TotalMonths())
+}
+
+func (suite *IntervalTestSuite) TestYearMonthIntervalValue() {
+	value, err := YearMonthInterval{Years: 5, Months: 3}.Value()
+	suite.NoError(err)
+	suite.Equal("+05-03", value)
+}
+
+func (suite *IntervalTestSuite) TestYearMonthIntervalScan() {
+	for _, src := range []any{"+05-03", []byte("+05-03"), YearMonthInterval{Years: 5, Months: 3}} {
+		var interval YearMonthInterval
+		suite.NoError(interval.Scan(src))
+		suite.Equal(YearMonthInterval{Years: 5, Months: 3}, interval)
+	}
+	interval := YearMonthInterval{Years: 1}
+	suite.EqualError(interval.Scan(nil), "E-EGOD-47: cannot scan NULL into 'INTERVAL YEAR TO MONTH', scan into a pointer instead")
+	suite.Equal(YearMonthInterval{Years: 1}, interval)
+	suite.EqualError(interval.Scan(1.5), "E-EGOD-43: could not parse '1.5' as 'INTERVAL YEAR TO MONTH'")
+}
+
+func (suite *IntervalTestSuite) TestParseDayToSecondInterval() {
+	for _, testCase := range []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"+02 12:50:10.123", 2*24*time.Hour + 12*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond},
+		{"2 12:50:10", 2*24*time.Hour + 12*time.Hour + 50*time.Minute + 10*time.Second},
+		{"-00 00:00:01.5", -1500 * time.Millisecond},
+		{"+00 00:00:00.000000001", time.Nanosecond},
+		{"+106751 23:47:16.854775807", time.Duration(math.MaxInt64)},
+	} {
+		suite.Run(testCase.value, func() {
+			duration, err := ParseDayToSecondInterval(testCase.value)
+			suite.NoError(err)
+			suite.Equal(testCase.expected, duration)
+		})
+	}
+}
+
+func (suite *IntervalTestSuite) TestParseDayToSecondIntervalFails() {
+	for _, value := range []string{"", "1", "1 12:00", "1 24:00:00", "1 00:60:00", "1 00:00:60", "1 00:00:00.1234567890",
+		"1 00:00:00.", "a 00:00:00", "1 00:00:0a", "1 00:00:00.a", "1 -1:00:00", "106752 00:00:00", "106751 23:47:16.854775808"} {
+		suite.Run(value, func() {
+			_, err := ParseDayToSecondInterval(value)
+			suite.EqualError(err, fmt.Sprintf("E-EGOD-43: could not parse '%s' as 'INTERVAL DAY TO SECOND'", value))
+		})
+	}
+}
+
+func (suite *IntervalTestSuite) TestFormatDayToSecondInterval() {
+	for _, testCase := range []struct {
+		duration time.Duration
+		expected string
+	}{
+		{2*24*time.Hour + 12*time.Hour + 50*time.Minute + 10*time.Second + 123*time.Millisecond, "+02 12:50:10.123"},
+		{-1500 * time.Millisecond, "-00 00:00:01.5"},
+		{0, "+00 00:00:00"},
+		{time.Nanosecond, "+00 00:00:00.000000001"},
+		{100 * 24 * time.Hour, "+100 00:00:00"},
+		{time.Duration(math.MaxInt64), "+106751 23:47:16.854775807"},
+		{time.Duration(math.MinInt64), "-106751 23:47:16.854775808"},
+	} {
+		suite.Run(testCase.expected, func() {
+			suite.Equal(testCase.expected, FormatDayToSecondInterval(testCase.duration))
+		})
+	}
+}
+
+func (suite *IntervalTestSuite) TestDayToSecondIntervalValue() {
+	value, err := DayToSecondInterval(36 * time.Hour).Value()
+	suite.NoError(err)
+	suite.Equal("+01 12:00:00", value)
+}
+
+func (suite *IntervalTestSuite) TestDayToSecondIntervalScan() {
+	for _, src := range []any{"+01 12:00:00", []byte("+01 12:00:00"), 36 * time.Hour} {
+		var interval DayToSecondInterval
+		suite.NoError(interval.Scan(src))
+		suite.Equal(36*time.Hour, interval.Duration())
+	}
+	interval := DayToSecondInterval(time.Second)
+	suite.EqualError(interval.Scan(nil), "E-EGOD-47: cannot scan NULL into 'INTERVAL DAY TO SECOND', scan into a pointer instead")
+	suite.Equal(DayToSecondInterval(time.Second), interval)
+	suite.EqualError(interval.Scan(1.5), "E-EGOD-43: could not parse '1.5' as 'INTERVAL DAY TO SECOND'")
+}
@@ -51,6 +51,18 @@ type ExecutePreparedStatementCommand struct {
 	Attributes      Attributes       `json:"attributes,omitempty"`
 }
 
+// GetHostsCommand requests the hosts of all cluster nodes. HostIP is the address the client is connected to,
+// the database returns the node addresses in the same network.
+type GetHostsCommand struct {
+	Command
+	HostIP string `json:"hostIp"`
+}
+
+type SetAttributesCommand struct {
+	Command
+	Attributes Attributes `json:"attributes"`
+}
+
 type Attributes struct {
 	Autocommit                  *bool  `json:"autocommit,omitempty"`
 	CompressionEnabled          *bool  `json:"compressionEnabled,omitempty"`
@@ -92,3 +104,9 @@ type SqlCommand struct {
 	SQLText    string     `json:"sqlText"`
 	Attributes Attributes `json:"attributes,omitempty"`
 }
+
+type BatchCommand struct {
+	Command
+	SQLTexts   []string   `json:"sqlTexts"`
+	Attributes Attributes `json:"attributes,omitempty"`
+}
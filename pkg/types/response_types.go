@@ -3,9 +3,19 @@ package types
 import "encoding/json"
 
 type BaseResponse struct {
-	Status       string          `json:"status"`
-	ResponseData json.RawMessage `json:"responseData"`
-	Exception    *Exception      `json:"exception"`
+	Status       string             `json:"status"`
+	ResponseData json.RawMessage    `json:"responseData"`
+	Exception    *Exception         `json:"exception"`
+	Attributes   *SessionAttributes `json:"attributes,omitempty"`
+}
+
+// SessionAttributes contains the session attributes reported by the server.
+// The server only sends attributes that changed, so all fields are optional.
+type SessionAttributes struct {
+	Autocommit                  *bool   `json:"autocommit,omitempty"`
+	CurrentSchema               *string `json:"currentSchema,omitempty"`
+	OpenTransaction             *bool   `json:"openTransaction,omitempty"`
+	SnapshotTransactionsEnabled *bool   `json:"snapshotTransactionsEnabled,omitempty"`
 }
 
 type Exception struct {
@@ -33,11 +43,22 @@ type PublicKeyResponse struct {
 	PublicKeyExponent string `json:"publicKeyExponent"`
 }
 
+type GetHostsResponse struct {
+	NumNodes int      `json:"numNodes"`
+	Nodes    []string `json:"nodes"`
+}
+
 type SqlQueriesResponse struct {
 	NumResults int               `json:"numResults"`
 	Results    []json.RawMessage `json:"results"`
 }
 
+// Result types of the entries in SqlQueriesResponse.Results
+const (
+	ResultTypeResultSet = "resultSet"
+	ResultTypeRowCount  = "rowCount"
+)
+
 type SqlQueryResponseRowCount struct {
 	ResultType string `json:"resultType"`
 	RowCount   int    `json:"rowCount"`
@@ -76,6 +97,8 @@ type SqlQueryColumnType struct {
 type CreatePreparedStatementResponse struct {
 	StatementHandle int           `json:"statementHandle"`
 	ParameterData   ParameterData `json:"parameterData,omitempty"`
+	// SqlQueriesResponse contains the result set metadata of queries, the result sets do not contain any data.
+	SqlQueriesResponse
 }
 
 type ParameterData struct {